@@ -0,0 +1,150 @@
+package jina
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// UncertaintyStrategy scores how unsure a classifier is about a prediction, see ActiveLearner.
+type UncertaintyStrategy int
+
+const (
+	// UncertaintyLeastConfidence scores 1 minus the score of the predicted label.
+	UncertaintyLeastConfidence UncertaintyStrategy = iota
+
+	// UncertaintyMargin scores 1 minus the gap between the two best labels.
+	UncertaintyMargin
+
+	// UncertaintyEntropy scores the normalized entropy of the label distribution, from 0 (certain) to 1.
+	UncertaintyEntropy
+)
+
+// LabelCandidate is an unlabeled input selected for human labeling.
+type LabelCandidate struct {
+	Index       int // Index of the input in the pool
+	Input       ClassificationInput
+	Prediction  string // The classifier's current guess, a useful default for the labeler
+	Score       float64
+	Uncertainty float64
+	Predictions []ClassificationLabel
+}
+
+// ActiveLearner closes the few-shot improvement loop of a classifier: Select picks the inputs of an
+// unlabeled pool the classifier is least sure about, a human labels them, and Teach trains the
+// classifier on the confirmed labels.
+type ActiveLearner struct {
+	Client *Client
+
+	// ClassifierID is the classifier being improved. While empty, Select classifies zero-shot with
+	// Model and Labels, and the first Teach creates the classifier and sets it.
+	ClassifierID string
+	Model        ClassificationModel
+	Labels       []string
+
+	Strategy UncertaintyStrategy
+
+	// BatchSize is the number of pool inputs per Classify call. Defaults to 256.
+	BatchSize int
+}
+
+// Select classifies the pool and returns the n inputs with the highest uncertainty, most uncertain first.
+func (l *ActiveLearner) Select(ctx context.Context, pool []ClassificationInput, n int, opts ...CallOption) ([]LabelCandidate, error) {
+	if l.ClassifierID == "" && len(l.Labels) == 0 {
+		return nil, errors.New("labels are required until a classifier is trained")
+	}
+	batchSize := l.BatchSize
+	if batchSize <= 0 {
+		batchSize = 256
+	}
+
+	candidates := make([]LabelCandidate, 0, len(pool))
+	for start := 0; start < len(pool); start += batchSize {
+		batch := pool[start:min(start+batchSize, len(pool))]
+		req := ClassificationRequest{ClassifierID: l.ClassifierID, Input: batch}
+		if l.ClassifierID == "" {
+			req.Model = l.Model
+			req.Labels = l.Labels
+		}
+		resp, err := l.Client.Classify(ctx, req, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("classify inputs %d-%d: %w", start, start+len(batch)-1, err)
+		}
+		for _, data := range resp.Data {
+			if data.Index < 0 || data.Index >= len(batch) {
+				continue
+			}
+			candidates = append(candidates, LabelCandidate{
+				Index:       start + data.Index,
+				Input:       batch[data.Index],
+				Prediction:  data.Prediction,
+				Score:       data.Score,
+				Uncertainty: l.Strategy.uncertainty(data),
+				Predictions: data.Predictions,
+			})
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b LabelCandidate) int {
+		return cmp.Compare(b.Uncertainty, a.Uncertainty)
+	})
+
+	return candidates[:min(n, len(candidates))], nil
+}
+
+// Teach trains the classifier on confirmed labels, creating it with Model on the first call.
+func (l *ActiveLearner) Teach(ctx context.Context, examples []TrainingExample, opts ...CallOption) (*TrainClassifierResponse, error) {
+	req := TrainClassifierRequest{ClassifierID: l.ClassifierID, Input: examples}
+	if l.ClassifierID == "" {
+		req.Model = l.Model
+	}
+
+	resp, err := l.Client.TrainClassifier(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if l.ClassifierID == "" {
+		l.ClassifierID = resp.ClassifierID
+	}
+
+	return resp, nil
+}
+
+// Label turns a labeled candidate into a training example.
+func (c LabelCandidate) Label(label string) TrainingExample {
+	return TrainingExample{Text: c.Input.Text, Image: c.Input.Image, Label: label}
+}
+
+// uncertainty scores a prediction. Without the full label distribution every strategy falls back
+// to least confidence.
+func (s UncertaintyStrategy) uncertainty(data ClassificationData) float64 {
+	scores := make([]float64, 0, len(data.Predictions))
+	for _, prediction := range data.Predictions {
+		scores = append(scores, prediction.Score)
+	}
+	slices.SortFunc(scores, func(a, b float64) int { return cmp.Compare(b, a) })
+
+	switch {
+	case s == UncertaintyMargin && len(scores) >= 2:
+		return 1 - (scores[0] - scores[1])
+	case s == UncertaintyEntropy && len(scores) >= 2:
+		var total, entropy float64
+		for _, score := range scores {
+			total += score
+		}
+		if total == 0 {
+			return 1
+		}
+		for _, score := range scores {
+			if p := score / total; p > 0 {
+				entropy -= p * math.Log(p)
+			}
+		}
+		return entropy / math.Log(float64(len(scores)))
+	default:
+		return 1 - data.Score
+	}
+}
@@ -0,0 +1,106 @@
+package jina
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// APIError is returned by every endpoint when the API responds with a non-200 status code.
+// Match it with errors.As to branch on the status:
+//
+//	var apiErr *jina.APIError
+//	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+//		...
+//	}
+type APIError struct {
+	StatusCode int
+
+	// Name is the error name reported by the API, e.g. "AuthenticationRequiredError". Empty when the
+	// API reports none.
+	Name string
+
+	// Detail is the human-readable error message reported by the API.
+	Detail string
+
+	// RequestID identifies the request in Jina's logs, from the X-Request-Id response header or else
+	// the correlation ID sent with the request.
+	RequestID string
+
+	// RetryAfter is the wait requested by the Retry-After header of 429 and 503 responses, zero if none.
+	RetryAfter time.Duration
+
+	// RateLimit holds the X-RateLimit headers of the response, zero if it carried none.
+	RateLimit RateLimit
+
+	// Body is the raw response body. Unlike Error and Detail, it is not redacted.
+	Body []byte
+
+	secrets []string // credentials sent with the request, redacted from Error
+}
+
+func (cl *Client) newAPIError(req *http.Request, resp *http.Response, body []byte) *APIError {
+	now := cl.cfg.Clock.Now()
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(CorrelationHeader),
+		RateLimit:  parseRateLimit(resp.Header, now),
+		Body:       body,
+		secrets:    requestSecrets(req),
+	}
+	e.RetryAfter, _ = parseRetryAfter(resp.Header, now)
+	if e.RequestID == "" {
+		e.RequestID = req.Header.Get(CorrelationHeader)
+	}
+
+	// The model APIs report {"detail": ...}, the Reader and Search APIs {"name": ..., "message": ...}
+	var errResp struct {
+		Name            string          `json:"name"`
+		Message         string          `json:"message"`
+		ReadableMessage string          `json:"readableMessage"`
+		Detail          json.RawMessage `json:"detail"`
+	}
+	if json.Unmarshal(body, &errResp) != nil {
+		return e
+	}
+	e.Name = errResp.Name
+	e.Detail = redactSecrets(cmp.Or(errResp.ReadableMessage, errResp.Message, detailText(errResp.Detail)), e.secrets)
+
+	return e
+}
+
+// detailText returns a string detail as is, and structured ones (e.g. validation errors) as JSON.
+func detailText(detail json.RawMessage) string {
+	if len(detail) == 0 || isJSONNull(detail) {
+		return ""
+	}
+	var text string
+	if json.Unmarshal(detail, &text) == nil {
+		return text
+	}
+
+	return string(detail)
+}
+
+// Error describes the response with any key material echoed by the API redacted.
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "API error: status %d", e.StatusCode)
+	if e.Name != "" {
+		fmt.Fprintf(&b, " %s", e.Name)
+	}
+	switch {
+	case e.Detail != "":
+		fmt.Fprintf(&b, ": %s", e.Detail)
+	case len(e.Body) > 0:
+		fmt.Fprintf(&b, ", body: %s", e.Body)
+	}
+	if e.RetryAfter > 0 {
+		fmt.Fprintf(&b, " (retry after %v)", e.RetryAfter)
+	}
+
+	return redactSecrets(b.String(), e.secrets)
+}
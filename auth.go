@@ -0,0 +1,64 @@
+package jina
+
+import (
+	"context"
+	"net/http"
+)
+
+// Authenticator adds credentials to every API request, replacing the default
+// "Authorization: Bearer <key>" header, e.g. for enterprise gateways expecting the key in
+// another header or an additional signature. apiKey is the key selected for the call
+// (WithAPIKey, WithAPIKeys, WithAPIKeyFunc or WithCallAPIKey) and may be empty.
+// The request body can be read through req.GetBody, e.g. to sign it.
+type Authenticator interface {
+	Authenticate(req *http.Request, apiKey string) error
+}
+
+// AuthenticatorFunc adapts a function to the Authenticator interface.
+type AuthenticatorFunc func(req *http.Request, apiKey string) error
+
+func (f AuthenticatorFunc) Authenticate(req *http.Request, apiKey string) error {
+	return f(req, apiKey)
+}
+
+// WithAuthenticator replaces the default bearer token authentication of all endpoints.
+func WithAuthenticator(authenticator Authenticator) Option {
+	return func(cfg *config) {
+		cfg.Authenticator = authenticator
+	}
+}
+
+// BearerAuth sends the key as "Authorization: Bearer <key>". It is the default.
+func BearerAuth() Authenticator {
+	return AuthenticatorFunc(func(req *http.Request, apiKey string) error {
+		if apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+		}
+		return nil
+	})
+}
+
+// HeaderAuth sends the key verbatim in the named header, e.g. HeaderAuth("X-Api-Key").
+// The header value is redacted from errors and logs like the Authorization header.
+func HeaderAuth(name string) Authenticator {
+	return AuthenticatorFunc(func(req *http.Request, apiKey string) error {
+		if apiKey != "" {
+			req.Header.Set(name, apiKey)
+		}
+		return nil
+	})
+}
+
+type apiKeyContextKey struct{}
+
+// callAPIKey returns the key the request was authenticated with, see setCallHeaders.
+func callAPIKey(req *http.Request) string {
+	apiKey, _ := req.Context().Value(apiKeyContextKey{}).(string)
+	return apiKey
+}
+
+// withCallAPIKey records the key selected for the call on the request context, so it can be attributed
+// to the response and redacted from errors whatever header the Authenticator put it in.
+func withCallAPIKey(req *http.Request, apiKey string) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), apiKeyContextKey{}, apiKey))
+}
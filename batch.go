@@ -0,0 +1,213 @@
+package jina
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// BatchOption configures Batch.
+type BatchOption func(*batchConfig)
+
+type batchConfig struct {
+	concurrency int
+	retries     int
+	backoff     time.Duration
+	interval    time.Duration
+	progress    func(done, total int)
+	clock       Clock
+}
+
+// WithBatchConcurrency sets the number of items processed at once. Defaults to 4.
+func WithBatchConcurrency(n int) BatchOption {
+	return func(cfg *batchConfig) {
+		cfg.concurrency = n
+	}
+}
+
+// WithBatchRetries retries a failed item up to n times, waiting backoff before the first retry
+// and doubling it after each. Only transport errors and 429 and 5xx API errors are retried. These
+// retries come on top of those of the client's retry policy, see WithRetry, so configure one of them.
+func WithBatchRetries(n int, backoff time.Duration) BatchOption {
+	return func(cfg *batchConfig) {
+		cfg.retries = n
+		cfg.backoff = backoff
+	}
+}
+
+// WithBatchRateLimit limits the number of calls started per second, retries included.
+func WithBatchRateLimit(perSecond float64) BatchOption {
+	return func(cfg *batchConfig) {
+		if perSecond > 0 {
+			cfg.interval = time.Duration(float64(time.Second) / perSecond)
+		}
+	}
+}
+
+// WithBatchProgress calls fn each time an item completes, successfully or not.
+// Calls are serialized, fn does not need to be safe for concurrent use.
+func WithBatchProgress(fn func(done, total int)) BatchOption {
+	return func(cfg *batchConfig) {
+		cfg.progress = fn
+	}
+}
+
+// WithBatchClock replaces the wall clock used for retry backoff and rate limiting. Defaults to SystemClock.
+func WithBatchClock(clock Clock) BatchOption {
+	return func(cfg *batchConfig) {
+		cfg.clock = clock
+	}
+}
+
+// BatchResult is the outcome of a single Batch item.
+type BatchResult[TResp any] struct {
+	Index    int // Index of the item in the input
+	Response TResp
+	Err      error
+}
+
+// Batch calls fn for every item with bounded concurrency, retrying and rate limiting the calls.
+// A result is returned for every item, in input order, so the successful part of a batch is kept
+// when some items fail. The error joins the errors of all failed items, it is nil when all succeeded.
+// Items not yet started when ctx is done fail with the context error.
+//
+//	results, err := jina.Batch(ctx, requests, func(ctx context.Context, req jina.EmbeddingsRequest) (*jina.EmbeddingsResponse, error) {
+//		return client.Embeddings(ctx, req)
+//	}, jina.WithBatchConcurrency(8))
+func Batch[TReq, TResp any](ctx context.Context, items []TReq, fn func(ctx context.Context, item TReq) (TResp, error), opts ...BatchOption) ([]BatchResult[TResp], error) {
+	cfg := &batchConfig{concurrency: 4, clock: SystemClock}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	cfg.concurrency = max(cfg.concurrency, 1)
+
+	b := &batch{cfg: cfg, total: len(items)}
+	results := make([]BatchResult[TResp], len(items))
+	sem := make(chan struct{}, cfg.concurrency)
+
+	var wg sync.WaitGroup
+	for i, item := range items {
+		results[i].Index = i
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			b.done()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i].Response, results[i].Err = batchCall(ctx, b, item, fn)
+			b.done()
+		}()
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("item %d: %w", result.Index, result.Err))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+func batchCall[TReq, TResp any](ctx context.Context, b *batch, item TReq, fn func(context.Context, TReq) (TResp, error)) (TResp, error) {
+	backoff := b.cfg.backoff
+	for attempt := 0; ; attempt++ {
+		if err := b.wait(ctx); err != nil {
+			var zero TResp
+			return zero, err
+		}
+
+		resp, err := fn(ctx, item)
+		if err == nil || attempt >= b.cfg.retries || !retryable(err) {
+			return resp, err
+		}
+
+		if err := b.cfg.clock.Sleep(ctx, backoff); err != nil {
+			return resp, err
+		}
+		backoff *= 2
+	}
+}
+
+type batch struct {
+	cfg   *batchConfig
+	total int
+
+	mu       sync.Mutex
+	next     time.Time // earliest start of the next call when rate limited
+	finished int
+}
+
+// wait blocks until the rate limit allows another call.
+func (b *batch) wait(ctx context.Context) error {
+	if b.cfg.interval <= 0 {
+		return ctx.Err()
+	}
+
+	b.mu.Lock()
+	now := b.cfg.clock.Now()
+	start := now
+	if b.next.After(now) {
+		start = b.next
+	}
+	b.next = start.Add(b.cfg.interval)
+	b.mu.Unlock()
+
+	return b.cfg.clock.Sleep(ctx, start.Sub(now))
+}
+
+func (b *batch) done() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.finished++
+	if b.cfg.progress != nil {
+		b.cfg.progress(b.finished, b.total)
+	}
+}
+
+// retryable reports whether a failed call may succeed when sent again: transport errors, throttling
+// and server errors are. Everything else, e.g. rejected or invalid requests, closed clients, open
+// circuits, local rate limits and undecodable responses, is not.
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	var netErr net.Error
+
+	return errors.As(err, &netErr)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
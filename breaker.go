@@ -0,0 +1,149 @@
+package jina
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned, as a *CircuitOpenError, for calls to an endpoint whose circuit breaker
+// is open, see WithCircuitBreaker.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// CircuitOpenError is returned for calls rejected by an open circuit breaker without being sent.
+type CircuitOpenError struct {
+	Endpoint Endpoint
+	Until    time.Time // When the breaker lets probe calls through again
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s: %v until %s", e.Endpoint, ErrCircuitOpen, e.Until.Format(time.RFC3339))
+}
+
+func (e *CircuitOpenError) Unwrap() error {
+	return ErrCircuitOpen
+}
+
+// CircuitBreaker configures the circuit breakers of WithCircuitBreaker.
+type CircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failed attempts opening the breaker. Defaults to 5.
+	FailureThreshold int
+
+	// OpenDuration is how long an open breaker rejects calls before letting probes through. Defaults to 30 seconds.
+	OpenDuration time.Duration
+
+	// HalfOpenProbes is the number of calls let through at once to probe a recovering endpoint.
+	// A successful probe closes the breaker, a failed one opens it again. Defaults to 1.
+	HalfOpenProbes int
+}
+
+// WithCircuitBreaker stops sending calls to an endpoint family that keeps failing, so a degraded
+// endpoint like the Reader does not block goroutines on calls bound to fail. Every endpoint gets its
+// own breaker: after FailureThreshold consecutive network errors or 5xx responses it opens and rejects
+// calls with a *CircuitOpenError for OpenDuration, then lets HalfOpenProbes calls through to find out
+// whether the endpoint recovered. 429 responses and cancelled calls do not count as failures.
+func WithCircuitBreaker(breaker CircuitBreaker) Option {
+	return func(cfg *config) {
+		if breaker.FailureThreshold <= 0 {
+			breaker.FailureThreshold = 5
+		}
+		if breaker.OpenDuration <= 0 {
+			breaker.OpenDuration = 30 * time.Second
+		}
+		if breaker.HalfOpenProbes <= 0 {
+			breaker.HalfOpenProbes = 1
+		}
+		cfg.CircuitBreaker = &breaker
+	}
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+type circuitBreakers struct {
+	cfg   CircuitBreaker
+	clock Clock
+
+	mu       sync.Mutex
+	circuits map[Endpoint]*circuit
+}
+
+type circuit struct {
+	state     circuitState
+	failures  int
+	openUntil time.Time
+	probes    int
+}
+
+func newCircuitBreakers(cfg CircuitBreaker, clock Clock) *circuitBreakers {
+	return &circuitBreakers{
+		cfg:      cfg,
+		clock:    clock,
+		circuits: make(map[Endpoint]*circuit),
+	}
+}
+
+// allow reports whether an attempt to the endpoint may be sent, returning a *CircuitOpenError if not.
+// Allowed attempts must be followed by record.
+func (b *circuitBreakers) allow(endpoint Endpoint) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(endpoint)
+	if c.state == circuitOpen && !b.clock.Now().Before(c.openUntil) {
+		c.state, c.probes = circuitHalfOpen, 0
+	}
+	switch {
+	case c.state == circuitOpen:
+		return &CircuitOpenError{Endpoint: endpoint, Until: c.openUntil}
+	case c.state == circuitHalfOpen && c.probes >= b.cfg.HalfOpenProbes:
+		// The probes in flight decide, the endpoint stays off limits meanwhile
+		return &CircuitOpenError{Endpoint: endpoint, Until: b.clock.Now()}
+	case c.state == circuitHalfOpen:
+		c.probes++
+	}
+
+	return nil
+}
+
+// record records the outcome of an attempt let through by allow.
+func (b *circuitBreakers) record(ctx context.Context, endpoint Endpoint, resp *http.Response, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(endpoint)
+	if c.state == circuitHalfOpen {
+		c.probes--
+	}
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// Cancelled by the caller, nothing learned about the endpoint
+	case err != nil || resp.StatusCode >= 500:
+		c.failures++
+		if c.state == circuitHalfOpen || c.failures >= b.cfg.FailureThreshold {
+			c.state = circuitOpen
+			c.openUntil = b.clock.Now().Add(b.cfg.OpenDuration)
+		}
+	default:
+		c.state, c.failures = circuitClosed, 0
+	}
+}
+
+// circuit returns the circuit of the endpoint. b.mu must be held.
+func (b *circuitBreakers) circuit(endpoint Endpoint) *circuit {
+	c, ok := b.circuits[endpoint]
+	if !ok {
+		c = &circuit{}
+		b.circuits[endpoint] = c
+	}
+
+	return c
+}
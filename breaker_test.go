@@ -0,0 +1,134 @@
+package jina
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	type step struct {
+		advance  time.Duration // Clock advance before the call
+		status   int           // Response status, if the call reaches the server
+		wantOpen bool          // Call rejected by the breaker without reaching the server
+		state    circuitState  // Breaker state after the call
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "opens after consecutive failures",
+			steps: []step{
+				{status: 500, state: circuitClosed},
+				{status: 500, state: circuitOpen},
+				{wantOpen: true, state: circuitOpen},
+				{advance: 9 * time.Second, wantOpen: true, state: circuitOpen},
+			},
+		},
+		{
+			name: "success resets the failure count",
+			steps: []step{
+				{status: 500, state: circuitClosed},
+				{status: 200, state: circuitClosed},
+				{status: 500, state: circuitClosed},
+				{status: 200, state: circuitClosed},
+			},
+		},
+		{
+			name: "successful probe closes",
+			steps: []step{
+				{status: 503, state: circuitClosed},
+				{status: 503, state: circuitOpen},
+				{advance: 10 * time.Second, status: 200, state: circuitClosed},
+				{status: 200, state: circuitClosed},
+			},
+		},
+		{
+			name: "failed probe opens again",
+			steps: []step{
+				{status: 500, state: circuitClosed},
+				{status: 500, state: circuitOpen},
+				{advance: 10 * time.Second, status: 500, state: circuitOpen},
+				{wantOpen: true, state: circuitOpen},
+				{advance: 10 * time.Second, status: 200, state: circuitClosed},
+			},
+		},
+		{
+			name: "throttling and client errors are no failures",
+			steps: []step{
+				{status: 429, state: circuitClosed},
+				{status: 429, state: circuitClosed},
+				{status: 400, state: circuitClosed},
+				{status: 500, state: circuitClosed},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			var status, hits atomic.Int32
+			cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				w.WriteHeader(int(status.Load()))
+				w.Write([]byte(`{"data":[]}`))
+			}, WithClock(clock), WithCircuitBreaker(CircuitBreaker{FailureThreshold: 2, OpenDuration: 10 * time.Second}))
+
+			for i, step := range tt.steps {
+				clock.Advance(step.advance)
+				status.Store(int32(step.status))
+				before := hits.Load()
+				_, err := cl.Embeddings(context.Background(), EmbeddingsRequest{Input: []EmbeddingInput{{Text: "a"}}})
+
+				var openErr *CircuitOpenError
+				if open := errors.As(err, &openErr); open != step.wantOpen {
+					t.Fatalf("step %d: got error %v, want circuit open %v", i, err, step.wantOpen)
+				}
+				if sent := hits.Load() > before; sent == step.wantOpen {
+					t.Errorf("step %d: call reached the server: %v", i, sent)
+				}
+				if !step.wantOpen && (err == nil) != (step.status == 200) {
+					t.Errorf("step %d: got error %v for status %d", i, err, step.status)
+				}
+				if state := cl.breakers.circuit(EndpointEmbeddings).state; state != step.state {
+					t.Errorf("step %d: breaker state %d, want %d", i, state, step.state)
+				}
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbes(t *testing.T) {
+	clock := newFakeClock()
+	breakers := newCircuitBreakers(CircuitBreaker{FailureThreshold: 1, OpenDuration: time.Second, HalfOpenProbes: 2}, clock)
+	ctx := context.Background()
+	failed := &http.Response{StatusCode: http.StatusInternalServerError}
+
+	if err := breakers.allow(EndpointReader); err != nil {
+		t.Fatal(err)
+	}
+	breakers.record(ctx, EndpointReader, failed, nil)
+	if err := breakers.allow(EndpointReader); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v, want ErrCircuitOpen", err)
+	}
+	if err := breakers.allow(EndpointEmbeddings); err != nil {
+		t.Fatalf("other endpoint: %v", err)
+	}
+
+	clock.Advance(time.Second)
+	for i := range 2 {
+		if err := breakers.allow(EndpointReader); err != nil {
+			t.Fatalf("probe %d: %v", i, err)
+		}
+	}
+	if err := breakers.allow(EndpointReader); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("third probe: got %v, want ErrCircuitOpen", err)
+	}
+	breakers.record(ctx, EndpointReader, &http.Response{StatusCode: http.StatusOK}, nil)
+	if state := breakers.circuit(EndpointReader).state; state != circuitClosed {
+		t.Errorf("state after successful probe %d, want closed", state)
+	}
+}
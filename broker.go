@@ -0,0 +1,317 @@
+package jina
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultBrokerReaderHeaders are the Reader options a Broker forwards unless BrokerPolicy.ReaderHeaders
+// is set. Options that spend more (X-Respond-With, X-With-Generated-Alt, X-No-Cache, ...), or reach
+// other systems on the caller's behalf (X-Proxy-Url, X-Set-Cookie, X-Engine, ...), are left out.
+var DefaultBrokerReaderHeaders = []string{
+	"Accept",
+	"X-Return-Format",
+	"X-Target-Selector",
+	"X-Remove-Selector",
+	"X-Wait-For-Selector",
+	"X-Retain-Images",
+	"X-With-Links-Summary",
+	"X-With-Images-Summary",
+	"X-Timeout",
+	"X-Locale",
+}
+
+// BrokerPolicy restricts what the callers of a Broker may do.
+type BrokerPolicy struct {
+	// User identifies the caller of a request, e.g. from a session the service has verified.
+	// Requests it returns an empty string for are rejected with 401. Defaults to the remote IP.
+	User func(r *http.Request) string
+
+	// RateLimit is the number of requests each user may make per RateWindow. Zero disables rate limiting.
+	RateLimit  int
+	RateWindow time.Duration // Defaults to a minute
+
+	// EmbeddingModels and EmbeddingTasks allow-list the embeddings options. Empty allows any.
+	EmbeddingModels []EmbeddingModel
+	EmbeddingTasks  []EmbeddingTask
+
+	// MaxInputs caps the inputs of an embeddings request. Zero only applies the client's Limits.
+	MaxInputs int
+
+	// ReaderHeaders allow-lists the Reader options callers may set, other headers are not forwarded.
+	// Defaults to DefaultBrokerReaderHeaders.
+	ReaderHeaders []string
+
+	// MaxBodyBytes caps the size of request bodies. Defaults to 1 MiB.
+	MaxBodyBytes int64
+}
+
+// Broker is an http.Handler proxying a safe subset of the API for browser and edge clients,
+// so they never see the API key: requests are authenticated with the client's credentials,
+// rate limited per user and restricted to the options allowed by the policy.
+//
+// It serves POST /embeddings, taking an embeddings request body, and POST /reader, taking
+// {"url": "..."} with Reader options as headers. Mount it under a prefix with http.StripPrefix:
+//
+//	mux.Handle("/jina/", http.StripPrefix("/jina", client.Broker(jina.BrokerPolicy{
+//		User:            userFromSession,
+//		RateLimit:       60,
+//		EmbeddingModels: []jina.EmbeddingModel{jina.EmbeddingModelV3},
+//	})))
+type Broker struct {
+	client *Client
+	policy BrokerPolicy
+	mux    *http.ServeMux
+
+	mu          sync.Mutex
+	windowStart time.Time
+	requests    map[string]int // Requests per user in the current window
+}
+
+// Broker returns a token-broker handler backed by the client.
+func (cl *Client) Broker(policy BrokerPolicy) *Broker {
+	if policy.User == nil {
+		policy.User = remoteIP
+	}
+	if policy.RateWindow <= 0 {
+		policy.RateWindow = time.Minute
+	}
+	if policy.ReaderHeaders == nil {
+		policy.ReaderHeaders = DefaultBrokerReaderHeaders
+	}
+	if policy.MaxBodyBytes <= 0 {
+		policy.MaxBodyBytes = 1 << 20
+	}
+
+	b := &Broker{
+		client:   cl,
+		policy:   policy,
+		mux:      http.NewServeMux(),
+		requests: make(map[string]int),
+	}
+	b.mux.HandleFunc("POST /embeddings", b.serveEmbeddings)
+	b.mux.HandleFunc("POST /reader", b.serveReader)
+
+	return b
+}
+
+func (b *Broker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user := b.policy.User(r)
+	if user == "" {
+		writeBrokerError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+		return
+	}
+	if wait, ok := b.allow(user); !ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(wait.Round(time.Second).Seconds())))
+		writeBrokerError(w, http.StatusTooManyRequests, errors.New("rate limit exceeded"))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, b.policy.MaxBodyBytes)
+	b.mux.ServeHTTP(w, r)
+}
+
+// allow counts a request of user against the rate limit, returning the time until the window
+// resets when the user is over it.
+func (b *Broker) allow(user string) (time.Duration, bool) {
+	if b.policy.RateLimit <= 0 {
+		return 0, true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.client.cfg.Clock.Now()
+	if now.Sub(b.windowStart) >= b.policy.RateWindow {
+		b.windowStart = now
+		clear(b.requests)
+	}
+	if b.requests[user] >= b.policy.RateLimit {
+		return b.windowStart.Add(b.policy.RateWindow).Sub(now), false
+	}
+	b.requests[user]++
+
+	return 0, true
+}
+
+func (b *Broker) serveEmbeddings(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeBrokerError(w, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+
+	var req EmbeddingsRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeBrokerError(w, http.StatusBadRequest, fmt.Errorf("decode request: %w", err))
+		return
+	}
+	if req.Model == "" {
+		req.Model = b.client.cfg.DefaultEmbeddingModel
+	}
+	if err := b.checkEmbeddings(req); err != nil {
+		writeBrokerError(w, http.StatusForbidden, err)
+		return
+	}
+	if err := b.client.cfg.Limits.checkEmbeddings(req); err != nil {
+		writeBrokerError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// The validated request is forwarded rather than the body, which may carry fields it does not decode
+	b.forward(w, r, EndpointEmbeddings, string(req.Model), req, http.Header{"Accept": {"application/json"}})
+}
+
+func (b *Broker) checkEmbeddings(req EmbeddingsRequest) error {
+	if len(b.policy.EmbeddingModels) > 0 && !slices.Contains(b.policy.EmbeddingModels, req.Model) {
+		return fmt.Errorf("model %q is not allowed", req.Model)
+	}
+	if len(b.policy.EmbeddingTasks) > 0 && !slices.Contains(b.policy.EmbeddingTasks, req.Task) {
+		return fmt.Errorf("task %q is not allowed", req.Task)
+	}
+	if b.policy.MaxInputs > 0 && len(req.Input) > b.policy.MaxInputs {
+		return fmt.Errorf("%d inputs exceed the limit of %d", len(req.Input), b.policy.MaxInputs)
+	}
+
+	return nil
+}
+
+func (b *Broker) serveReader(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		URL string `json:"url"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeBrokerError(w, http.StatusBadRequest, fmt.Errorf("decode request: %w", err))
+		return
+	}
+	if u, err := url.Parse(req.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		writeBrokerError(w, http.StatusBadRequest, fmt.Errorf("invalid URL %q", req.URL))
+		return
+	}
+
+	header := make(http.Header)
+	for _, name := range b.policy.ReaderHeaders {
+		if values := r.Header.Values(name); len(values) > 0 {
+			header[http.CanonicalHeaderKey(name)] = values
+		}
+	}
+
+	b.forward(w, r, EndpointReader, header.Get("X-Respond-With"), req, header)
+}
+
+// forward sends body to the endpoint with the client's credentials and streams the response to w,
+// reporting the usage of the response for model like the client's own calls.
+func (b *Broker) forward(w http.ResponseWriter, r *http.Request, endpoint Endpoint, model string, body any, header http.Header) {
+	cl := b.client
+	var streaming bool
+	status, err := func() (int, error) {
+		url, err := cl.endpointURL(endpoint)
+		if err != nil {
+			return http.StatusServiceUnavailable, err
+		}
+		reqBody, err := newRequestBody(body)
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+		defer reqBody.release()
+		if err := cl.cfg.Limits.checkRequestBody(reqBody); err != nil {
+			return http.StatusRequestEntityTooLarge, err
+		}
+
+		httpReq, err := cl.newRequest(r.Context(), url, reqBody)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		for name, values := range header {
+			httpReq.Header[name] = values
+		}
+
+		var meta ResponseMeta
+		call := newCallConfig([]CallOption{WithResponseMeta(&meta)})
+		if httpReq, err = cl.setCallHeaders(httpReq, call); err != nil {
+			return http.StatusInternalServerError, err
+		}
+
+		resp := getBuffer()
+		defer putBuffer(resp)
+		err = cl.sendStreaming(endpoint, httpReq, call, func(respBody io.Reader) error {
+			if contentType := meta.Header.Get("Content-Type"); contentType != "" {
+				w.Header().Set("Content-Type", contentType)
+			}
+			w.Header().Set(CorrelationHeader, meta.CorrelationID)
+			streaming = true
+			_, err := io.Copy(w, io.TeeReader(respBody, resp))
+			return err
+		})
+		if err == nil {
+			cl.reportUsage(r.Context(), endpoint, model, forwardedUsage(endpoint, resp.Bytes()))
+		}
+
+		return http.StatusBadGateway, err
+	}()
+	if err == nil || streaming {
+		// A response cut off mid-stream cannot be turned into an error response anymore
+		return
+	}
+
+	// API errors are relayed with their status, other failures never reached the API
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(apiErr.StatusCode)
+		w.Write([]byte(redactSecrets(string(apiErr.Body), apiErr.secrets)))
+		return
+	}
+	writeBrokerError(w, status, err)
+}
+
+// forwardedUsage decodes the usage of a response relayed by forward. Responses that are not JSON,
+// such as plain-text Reader responses, report none.
+func forwardedUsage(endpoint Endpoint, body []byte) Usage {
+	switch endpoint {
+	case EndpointReader:
+		var resp StructuredReaderResponse
+		if json.Unmarshal(body, &resp) == nil {
+			return Usage{TotalTokens: resp.Data.Usage.Tokens}
+		}
+	default:
+		var resp struct {
+			Usage Usage `json:"usage"`
+		}
+		if json.Unmarshal(body, &resp) == nil {
+			return resp.Usage
+		}
+	}
+
+	return Usage{}
+}
+
+func writeBrokerError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	var body bytes.Buffer
+	json.NewEncoder(&body).Encode(map[string]string{"error": err.Error()})
+	w.Write(body.Bytes())
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -0,0 +1,114 @@
+package jina
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+)
+
+// CallOption configures a single API call without affecting the client.
+type CallOption func(*callConfig)
+
+type callConfig struct {
+	rawResponse *[]byte
+	apiKey      string
+	timeout     time.Duration
+	header      http.Header
+	eu          bool
+
+	idempotencyKey string
+
+	robots *RobotsChecker
+
+	streamTee     io.Writer
+	streamDataTee io.Writer
+
+	proxies *ProxyPool
+
+	responseMeta  *ResponseMeta
+	correlationID string
+
+	// Recorded while the call runs, for the metrics hooks and the response
+	started    time.Time
+	statusCode int
+	usage      Usage
+	meta       Meta
+
+	resultFilters []resultFilter
+
+	deepSearchActivity func(DeepSearchActivity)
+}
+
+func newCallConfig(options []CallOption) *callConfig {
+	call := &callConfig{}
+	for _, option := range options {
+		option(call)
+	}
+
+	return call
+}
+
+// WithRawResponse stores the untouched response body in dst, alongside the decoded result.
+// The body is captured for error responses too. For streaming calls dst receives the raw event stream.
+func WithRawResponse(dst *[]byte) CallOption {
+	return func(call *callConfig) {
+		call.rawResponse = dst
+	}
+}
+
+// WithCallAPIKey overrides the client's API key for a single call.
+// Useful for multi-tenant backends that call Jina on behalf of different customers through one client.
+func WithCallAPIKey(apiKey string) CallOption {
+	return func(call *callConfig) {
+		call.apiKey = apiKey
+	}
+}
+
+// WithTimeout bounds the total duration of a single call, including retries, replacing the
+// endpoint's timeout set by WithEndpointTimeout. The call's context still applies when it is shorter.
+func WithTimeout(d time.Duration) CallOption {
+	return func(call *callConfig) {
+		call.timeout = d
+	}
+}
+
+// WithCallHeader sends an extra header with a single call, e.g. a Reader option this package does not
+// model. It replaces the header set with WithHeaders or from the request fields, only authentication
+// takes precedence.
+func WithCallHeader(key, value string) CallOption {
+	return func(call *callConfig) {
+		if call.header == nil {
+			call.header = make(http.Header)
+		}
+		call.header.Add(key, value)
+	}
+}
+
+// WithCallEUCompliance routes a single call through the EU-hosted endpoints, like WithEUCompliance
+// does for every call of the client. Calls cannot opt out of a client's EU compliance.
+func WithCallEUCompliance() CallOption {
+	return func(call *callConfig) {
+		call.eu = true
+	}
+}
+
+// WithIdempotencyKey sends key in the Idempotency-Key header so the API can deduplicate
+// repeated deliveries of the same call, e.g. classifier training or retried long operations.
+// The same key is sent on every attempt of the call. See WithIdempotencyKeys to generate keys.
+func WithIdempotencyKey(key string) CallOption {
+	return func(call *callConfig) {
+		call.idempotencyKey = key
+	}
+}
+
+// euCompliance reports whether the call is routed through the EU-hosted endpoints.
+func (call *callConfig) euCompliance(cfg *config) bool {
+	return call.eu || cfg.EUCompliance
+}
+
+func (call *callConfig) captureRaw(body []byte) {
+	if call.rawResponse != nil {
+		*call.rawResponse = bytes.Clone(body)
+	}
+}
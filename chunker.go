@@ -0,0 +1,165 @@
+package jina
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Chunker splits text locally on domain-specific boundaries, e.g. markdown headings or legal
+// section markers, as an alternative to the Segmenter's generic semantic chunking.
+// The zero value returns the whole text as a single chunk.
+//
+//	chunker := jina.Chunker{Delimiters: []string{"\n## "}, MaxTokens: 512}
+//	chunks := chunker.Chunk(document)
+type Chunker struct {
+	// Delimiters start a new chunk wherever one of them occurs. The delimiter stays at the start of the new chunk.
+	Delimiters []string
+
+	// Pattern starts a new chunk at the start of every match, e.g. `(?m)^§ \d+`. Used together with Delimiters.
+	Pattern *regexp.Regexp
+
+	// MaxTokens is the token budget of a chunk. Sections exceeding it are split further at line breaks,
+	// then at whitespace. Zero disables the budget.
+	MaxTokens int
+
+	// Merge combines consecutive sections into one chunk while they fit in MaxTokens.
+	Merge bool
+
+	// CountTokens counts the tokens of a piece of text. Defaults to ApproxTokens.
+	// Use the Segmenter's NumTokens for exact counts of a specific tokenizer.
+	CountTokens func(text string) int
+}
+
+// Chunk is a piece of text produced by a Chunker.
+type Chunk struct {
+	Text   string
+	Start  int // Byte offset of the chunk in the original text
+	End    int // Byte offset just past the chunk
+	Tokens int
+}
+
+// ApproxTokens estimates the number of tokens of text at four characters per token,
+// which is close for English prose with common BPE tokenizers.
+func ApproxTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+// Chunk splits text into chunks. Chunks consisting only of whitespace are dropped.
+func (c *Chunker) Chunk(text string) []Chunk {
+	var chunks []Chunk
+	for _, section := range c.sections(text) {
+		for _, chunk := range c.enforceBudget(text, section[0], section[1]) {
+			if strings.TrimSpace(chunk.Text) == "" {
+				continue
+			}
+			if c.Merge && len(chunks) > 0 {
+				last := &chunks[len(chunks)-1]
+				if tokens := c.count(text[last.Start:chunk.End]); c.MaxTokens <= 0 || tokens <= c.MaxTokens {
+					*last = Chunk{Text: text[last.Start:chunk.End], Start: last.Start, End: chunk.End, Tokens: tokens}
+					continue
+				}
+			}
+			chunks = append(chunks, chunk)
+		}
+	}
+
+	return chunks
+}
+
+// sections returns the [start, end) offsets of the text between boundaries.
+func (c *Chunker) sections(text string) [][2]int {
+	boundaries := map[int]bool{}
+	for _, delimiter := range c.Delimiters {
+		if delimiter == "" {
+			continue
+		}
+		for offset := 0; ; {
+			i := strings.Index(text[offset:], delimiter)
+			if i < 0 {
+				break
+			}
+			boundaries[offset+i] = true
+			offset += i + len(delimiter)
+		}
+	}
+	if c.Pattern != nil {
+		for _, match := range c.Pattern.FindAllStringIndex(text, -1) {
+			boundaries[match[0]] = true
+		}
+	}
+
+	var sections [][2]int
+	start := 0
+	for i := 1; i < len(text); i++ {
+		if boundaries[i] {
+			sections = append(sections, [2]int{start, i})
+			start = i
+		}
+	}
+
+	return append(sections, [2]int{start, len(text)})
+}
+
+// enforceBudget splits text[start:end] into chunks within MaxTokens.
+func (c *Chunker) enforceBudget(text string, start, end int) []Chunk {
+	tokens := c.count(text[start:end])
+	if c.MaxTokens <= 0 || tokens <= c.MaxTokens {
+		return []Chunk{{Text: text[start:end], Start: start, End: end, Tokens: tokens}}
+	}
+
+	for _, split := range []func(rune) bool{isNewline, unicode.IsSpace} {
+		if cut := c.cut(text, start, end, split); cut > start {
+			return append(c.enforceBudget(text, start, cut), c.enforceBudget(text, cut, end)...)
+		}
+	}
+
+	// A single word over budget: split it at the last rune that fits
+	cut := start
+	for i := range text[start:end] {
+		if i > 0 && c.count(text[start:start+i]) > c.MaxTokens {
+			break
+		}
+		cut = start + i
+	}
+	if cut == start {
+		_, size := utf8.DecodeRuneInString(text[start:end])
+		cut = start + size
+	}
+
+	return append([]Chunk{{Text: text[start:cut], Start: start, End: cut, Tokens: c.count(text[start:cut])}}, c.enforceBudget(text, cut, end)...)
+}
+
+// cut returns the offset just past the last separator matching split, such that text[start:cut]
+// fits in the budget, or start if there is none.
+func (c *Chunker) cut(text string, start, end int, split func(rune) bool) int {
+	cut := start
+	for i, r := range text[start:end] {
+		if !split(r) {
+			continue
+		}
+		next := start + i + utf8.RuneLen(r)
+		if next >= end {
+			break
+		}
+		if c.count(text[start:next]) > c.MaxTokens {
+			break
+		}
+		cut = next
+	}
+
+	return cut
+}
+
+func (c *Chunker) count(text string) int {
+	if c.CountTokens != nil {
+		return c.CountTokens(text)
+	}
+
+	return ApproxTokens(text)
+}
+
+func isNewline(r rune) bool {
+	return r == '\n'
+}
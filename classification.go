@@ -1,11 +1,8 @@
 package jina
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 type ClassificationModel string
@@ -46,7 +43,11 @@ type ClassificationInput struct {
 }
 
 // MarshalJSON implements custom marshaling to support both string and object formats.
+// Inputs with both text and image are sent as a combined object, which multimodal models accept.
 func (c ClassificationInput) MarshalJSON() ([]byte, error) {
+	if c.Image != "" && c.Text != "" {
+		return json.Marshal(map[string]string{"text": c.Text, "image": c.Image})
+	}
 	if c.Image != "" {
 		return json.Marshal(map[string]string{"image": c.Image})
 	}
@@ -64,9 +65,17 @@ func NewClassificationInputImage(imageURLOrBase64 string) ClassificationInput {
 	return ClassificationInput{Image: imageURLOrBase64}
 }
 
+// NewClassificationInputTextImage creates a combined text and image input, e.g. a product title
+// and its photo. Only supported by multimodal models (jina-clip-v2, jina-embeddings-v4).
+func NewClassificationInputTextImage(text, imageURLOrBase64 string) ClassificationInput {
+	return ClassificationInput{Text: text, Image: imageURLOrBase64}
+}
+
 type ClassificationResponse struct {
+	Model string               `json:"model,omitempty"`
 	Data  []ClassificationData `json:"data"`
 	Usage Usage                `json:"usage"`
+	Meta  Meta                 `json:"-"`
 }
 
 type ClassificationData struct {
@@ -83,44 +92,26 @@ type ClassificationLabel struct {
 }
 
 // Classify calls the Jina Classifier API to classify text or images into categories.
-func (cl *Client) Classify(ctx context.Context, req ClassificationRequest) (*ClassificationResponse, error) {
-	url := "https://api.jina.ai/v1/classify"
-
-	jsonData, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "application/json")
-	if cl.cfg.APIKey != "" {
-		httpReq.Header.Set("Authorization", "Bearer "+cl.cfg.APIKey)
+func (cl *Client) Classify(ctx context.Context, req ClassificationRequest, opts ...CallOption) (*ClassificationResponse, error) {
+	if req.Model == "" && req.ClassifierID == "" {
+		req.Model = cl.cfg.DefaultClassificationModel
 	}
-
-	client := &http.Client{}
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	if err := cl.cfg.Limits.checkClassification(req); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		var errResp map[string]interface{}
-		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil {
-			return nil, fmt.Errorf("API error: %v", errResp)
-		}
-		return nil, fmt.Errorf("API error with status code: %d", resp.StatusCode)
-	}
+	return withModelFallback(cl, EndpointClassify, string(req.Model), func(model string) (*ClassificationResponse, error) {
+		req.Model = ClassificationModel(model)
 
-	var result ClassificationResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+		var result ClassificationResponse
+		if err := cl.postJSON(ctx, EndpointClassify, req, &result, newCallConfig(opts)); err != nil {
+			return nil, err
+		}
+		if result.Model == "" {
+			result.Model = model
+		}
+		cl.reportUsage(ctx, EndpointClassify, model, result.Usage)
 
-	return &result, nil
+		return &result, nil
+	})
 }
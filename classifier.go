@@ -0,0 +1,160 @@
+package jina
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// TrainingExample is a labeled text or image used to train a classifier.
+type TrainingExample struct {
+	Text  string `json:"text,omitempty"`
+	Image string `json:"image,omitempty"`
+	Label string `json:"label"`
+}
+
+type TrainClassifierRequest struct {
+	// Model is the identifier of the model to train a new classifier on.
+	// Required if ClassifierID is not provided.
+	Model ClassificationModel `json:"model,omitempty"`
+
+	// ClassifierID is the identifier of an existing classifier to train further.
+	// If not provided, a new classifier is created.
+	ClassifierID string `json:"classifier_id,omitempty"`
+
+	// Access is the visibility of a new classifier: "private" (default) or "public".
+	Access string `json:"access,omitempty"`
+
+	// NumIters is the number of training iterations. Default: 10.
+	NumIters int `json:"num_iters,omitempty"`
+
+	// Input is the array of labeled examples.
+	Input []TrainingExample `json:"input"`
+}
+
+type TrainClassifierResponse struct {
+	ClassifierID string `json:"classifier_id"`
+	NumSamples   int    `json:"num_samples"`
+	Usage        Usage  `json:"usage"`
+	Meta         Meta   `json:"-"`
+}
+
+// TrainClassifier calls the Jina Train API to create or update a few-shot classifier.
+// Use the returned ClassifierID with Classify.
+func (cl *Client) TrainClassifier(ctx context.Context, req TrainClassifierRequest, opts ...CallOption) (*TrainClassifierResponse, error) {
+	if req.Model == "" && req.ClassifierID == "" {
+		req.Model = cl.cfg.DefaultClassificationModel
+	}
+	if len(req.Input) == 0 {
+		return nil, fmt.Errorf("at least one training example is required")
+	}
+
+	var result TrainClassifierResponse
+	if err := cl.postJSON(ctx, EndpointTrain, req, &result, newCallConfig(opts)); err != nil {
+		return nil, err
+	}
+	cl.reportUsage(ctx, EndpointTrain, string(req.Model), result.Usage)
+
+	return &result, nil
+}
+
+// classifierDefinitionVersion is the version of the ClassifierDefinition format written by Export.
+const classifierDefinitionVersion = 1
+
+// ClassifierDefinition is the portable configuration of a trained classifier: its model, labels and
+// example set. Definitions can be versioned alongside the code using them and recreated in another
+// environment or account, see ImportClassifierDefinition and Client.RestoreClassifier.
+type ClassifierDefinition struct {
+	Version      int                 `json:"version"`
+	ClassifierID string              `json:"classifier_id,omitempty"` // Classifier trained from the definition, if any
+	Model        ClassificationModel `json:"model"`
+	Labels       []string            `json:"labels"`
+	Access       string              `json:"access,omitempty"`
+	NumIters     int                 `json:"num_iters,omitempty"`
+	Examples     []TrainingExample   `json:"examples"`
+}
+
+// NewClassifierDefinition returns the definition of the classifier trained by req, with the ID from resp
+// when given. Labels are collected from the examples.
+func NewClassifierDefinition(req TrainClassifierRequest, resp *TrainClassifierResponse) *ClassifierDefinition {
+	def := &ClassifierDefinition{
+		Version:      classifierDefinitionVersion,
+		ClassifierID: req.ClassifierID,
+		Model:        req.Model,
+		Access:       req.Access,
+		NumIters:     req.NumIters,
+		Examples:     slices.Clone(req.Input),
+	}
+	if resp != nil {
+		def.ClassifierID = resp.ClassifierID
+	}
+	for _, example := range def.Examples {
+		if !slices.Contains(def.Labels, example.Label) {
+			def.Labels = append(def.Labels, example.Label)
+		}
+	}
+	slices.Sort(def.Labels)
+
+	return def
+}
+
+// Export writes the definition to w as indented JSON, stable across exports of the same definition.
+func (d *ClassifierDefinition) Export(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(d); err != nil {
+		return fmt.Errorf("encode classifier definition: %w", err)
+	}
+
+	return nil
+}
+
+// ImportClassifierDefinition reads a definition written by Export and validates it.
+func ImportClassifierDefinition(r io.Reader) (*ClassifierDefinition, error) {
+	var def ClassifierDefinition
+	if err := json.NewDecoder(r).Decode(&def); err != nil {
+		return nil, fmt.Errorf("decode classifier definition: %w", err)
+	}
+	if def.Version != classifierDefinitionVersion {
+		return nil, fmt.Errorf("unsupported classifier definition version %d", def.Version)
+	}
+	if def.Model == "" {
+		return nil, fmt.Errorf("classifier definition has no model")
+	}
+	if len(def.Examples) == 0 {
+		return nil, fmt.Errorf("classifier definition has no examples")
+	}
+	for i, example := range def.Examples {
+		if example.Text == "" && example.Image == "" {
+			return nil, fmt.Errorf("example %d has neither text nor image", i)
+		}
+		if !slices.Contains(def.Labels, example.Label) {
+			return nil, fmt.Errorf("example %d has label %q missing from the labels", i, example.Label)
+		}
+	}
+
+	return &def, nil
+}
+
+// RestoreClassifier trains a new classifier from the examples of def, e.g. to recreate a versioned
+// classifier in another environment, and returns a copy of def carrying the new ClassifierID.
+func (cl *Client) RestoreClassifier(ctx context.Context, def *ClassifierDefinition, opts ...CallOption) (*ClassifierDefinition, error) {
+	resp, err := cl.TrainClassifier(ctx, TrainClassifierRequest{
+		Model:    def.Model,
+		Access:   def.Access,
+		NumIters: def.NumIters,
+		Input:    def.Examples,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	restored := *def
+	restored.ClassifierID = resp.ClassifierID
+	restored.Labels = slices.Clone(def.Labels)
+	restored.Examples = slices.Clone(def.Examples)
+
+	return &restored, nil
+}
@@ -0,0 +1,45 @@
+package jina
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the time source used for retry backoff, throttle pauses, key throttling windows, batch
+// rate limiting, Retry-After dates, rate-limit resets, latencies and news freshness. Tests can
+// substitute a fake clock so that retry behavior runs instantly and deterministically, see WithClock
+// and WithBatchClock.
+type Clock interface {
+	Now() time.Time
+
+	// Sleep blocks for d or until ctx is done, returning ctx.Err() in the latter case.
+	Sleep(ctx context.Context, d time.Duration) error
+
+	// AfterFunc calls f in its own goroutine after d. The returned stop function cancels the call,
+	// reporting whether it was still pending, like time.Timer.Stop.
+	AfterFunc(d time.Duration, f func()) (stop func() bool)
+}
+
+// WithClock replaces the wall clock used by the client. Defaults to SystemClock.
+func WithClock(clock Clock) Option {
+	return func(cfg *config) {
+		cfg.Clock = clock
+	}
+}
+
+// SystemClock is the Clock backed by the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error {
+	return sleep(ctx, d)
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) func() bool {
+	return time.AfterFunc(d, f).Stop
+}
@@ -0,0 +1,120 @@
+package jina
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose time only moves when Sleep or Advance is called. Sleep returns at once,
+// recording the wait, and timers fire synchronously when Advance reaches them.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at   time.Time
+	f    func()
+	done bool // Fired or stopped
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.sleeps = append(c.sleeps, d)
+	c.mu.Unlock()
+	c.Advance(d)
+
+	return nil
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) func() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer := &fakeTimer{at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, timer)
+
+	return func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		pending := !timer.done
+		timer.done = true
+		return pending
+	}
+}
+
+// Advance moves the time forward by d, firing the timers due by then.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	var due []func()
+	for _, timer := range c.timers {
+		if !timer.done && !timer.at.After(c.now) {
+			timer.done = true
+			due = append(due, timer.f)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, f := range due {
+		f()
+	}
+}
+
+// Sleeps returns the waits passed to Sleep so far.
+func (c *fakeClock) Sleeps() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]time.Duration(nil), c.sleeps...)
+}
+
+// newTestClient returns a client sending every endpoint to a local server handling the requests with
+// handler, on the path /<endpoint>.
+func newTestClient(tb testing.TB, handler http.HandlerFunc, opts ...Option) *Client {
+	tb.Helper()
+	srv := httptest.NewServer(handler)
+	tb.Cleanup(srv.Close)
+
+	urls := make(map[Endpoint]string, len(Endpoints))
+	for _, endpoint := range Endpoints {
+		urls[endpoint] = srv.URL + "/" + string(endpoint)
+	}
+
+	return NewClient(append([]Option{
+		WithAPIKey("jina_test-api-key"),
+		WithHTTPClient(srv.Client()),
+		WithBaseURLs(urls),
+	}, opts...)...)
+}
+
+// waitUntil polls cond until it holds, failing the test after a few seconds.
+func waitUntil(tb testing.TB, cond func() bool) {
+	tb.Helper()
+	for deadline := time.Now().Add(5 * time.Second); !cond(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			tb.Fatal("timed out waiting for condition")
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fritzkeyzer/gojina"
+)
+
+func runDoctor(args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	eu := fs.Bool("eu", false, "check with EU compliance routing enabled")
+	timeout := fs.Duration("timeout", 15*time.Second, "overall time limit for all checks")
+	fs.Parse(args)
+
+	options := []jina.Option{jina.WithAPIKey(os.Getenv("JINA_API_KEY"))}
+	if *eu {
+		options = append(options, jina.WithEUCompliance())
+	}
+	client := jina.NewClient(options...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	result, err := client.Ping(ctx)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	if result.KeyValid {
+		fmt.Fprintln(tw, "API key:\tok")
+	} else {
+		fmt.Fprintf(tw, "API key:\tFAILED, %s (%v)\n", result.KeyStatus, result.KeyError)
+	}
+	if rl := result.RateLimit; rl.Limit > 0 {
+		fmt.Fprintf(tw, "Rate limit:\t%d requests, %d remaining, resets in %s\n", rl.Limit, rl.Remaining, rl.Reset.Round(time.Second))
+	} else {
+		fmt.Fprintln(tw, "Rate limit:\tnot reported")
+	}
+	if result.EUCompliance {
+		fmt.Fprintln(tw, "EU routing:\tenabled")
+	} else {
+		fmt.Fprintln(tw, "EU routing:\tdisabled")
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "ENDPOINT\tURL\tSTATUS\tLATENCY")
+	for _, status := range result.Endpoints {
+		name := string(status.Endpoint)
+		if status.EU {
+			name += " (eu)"
+		}
+		state := "ok"
+		if !status.Reachable {
+			state = fmt.Sprintf("unreachable: %v", status.Err)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", name, status.URL, state, status.Latency.Round(time.Millisecond))
+	}
+	tw.Flush()
+
+	if !result.OK() {
+		return errors.New("one or more checks failed")
+	}
+	return nil
+}
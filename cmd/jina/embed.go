@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fritzkeyzer/gojina"
+	"github.com/fritzkeyzer/gojina/jobs"
+)
+
+// embedInput is a line of the input file. ID is an optional caller key copied to the output.
+type embedInput struct {
+	ID    json.RawMessage `json:"id,omitempty"`
+	Text  string          `json:"text,omitempty"`
+	Image string          `json:"image,omitempty"`
+	PDF   string          `json:"pdf,omitempty"`
+}
+
+// embedOutput is a line of the output file.
+type embedOutput struct {
+	Index     int             `json:"index"`
+	ID        json.RawMessage `json:"id,omitempty"`
+	Embedding []float32       `json:"embedding"`
+}
+
+func runEmbed(args []string) error {
+	fs := flag.NewFlagSet("embed", flag.ExitOnError)
+	input := fs.String("input", "", "JSONL file with one {\"id\", \"text\"|\"image\"|\"pdf\"} object per line (required)")
+	output := fs.String("output", "", "JSONL file receiving one {\"index\", \"id\", \"embedding\"} object per input (required)")
+	model := fs.String("model", string(jina.EmbeddingModelV3), "embedding model")
+	task := fs.String("task", "", "embedding task, e.g. retrieval.passage")
+	dimensions := fs.Int("dimensions", 0, "truncate embeddings to this many dimensions")
+	batchSize := fs.Int("batch-size", 128, "inputs per API call")
+	concurrency := fs.Int("concurrency", 4, "API calls in flight")
+	retries := fs.Int("retries", 3, "retries of a failed batch within a run")
+	resume := fs.Bool("resume", false, "continue an interrupted run, appending to the output")
+	stateDir := fs.String("state", "", "directory holding the job state (default: <output>.state)")
+	fs.Parse(args)
+
+	if *input == "" || *output == "" {
+		fs.Usage()
+		return errors.New("-input and -output are required")
+	}
+	if *stateDir == "" {
+		*stateDir = *output + ".state"
+	}
+
+	inputs, err := readEmbedInputs(*input)
+	if err != nil {
+		return err
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if *resume {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	out, err := os.OpenFile(*output, flags, 0o644)
+	if err != nil {
+		return fmt.Errorf("open output: %w", err)
+	}
+	defer out.Close()
+	w := bufio.NewWriter(out)
+
+	store, err := jobs.NewFileStore(*stateDir)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var mu sync.Mutex
+	var done atomic.Int64
+	sink := func(_ context.Context, index int, embedding []float32) error {
+		line, err := json.Marshal(embedOutput{Index: index, ID: inputs[index].ID, Embedding: embedding})
+		if err != nil {
+			return err
+		}
+		done.Add(1)
+
+		mu.Lock()
+		defer mu.Unlock()
+		w.Write(line)
+		return w.WriteByte('\n')
+	}
+
+	client := jina.NewClient(jina.WithAPIKey(os.Getenv("JINA_API_KEY")))
+	runner := jobs.NewEmbeddings(client, store, sink,
+		jobs.WithBatchSize(*batchSize),
+		jobs.WithConcurrency(*concurrency),
+		jobs.WithRetries(*retries, time.Second),
+	)
+
+	id := strings.TrimSuffix(filepath.Base(*input), filepath.Ext(*input))
+	if *resume {
+		progress, err := runner.Status(ctx, id)
+		if err != nil {
+			return fmt.Errorf("resume: %w", err)
+		}
+		done.Store(int64(progress.Done))
+	}
+
+	stopProgress := showProgress(&done, len(inputs))
+	if *resume {
+		err = runner.Resume(ctx, id)
+	} else {
+		req := jina.EmbeddingsRequest{
+			Model:      jina.EmbeddingModel(*model),
+			Task:       jina.EmbeddingTask(*task),
+			Dimensions: *dimensions,
+		}
+		for _, in := range inputs {
+			req.Input = append(req.Input, jina.EmbeddingInput{Text: in.Text, Image: in.Image, PDF: in.PDF})
+		}
+		err = runner.Start(ctx, id, req)
+	}
+	stopProgress()
+
+	mu.Lock()
+	flushErr := w.Flush()
+	mu.Unlock()
+	if flushErr != nil {
+		return fmt.Errorf("write output: %w", flushErr)
+	}
+
+	if progress, statusErr := runner.Status(context.WithoutCancel(ctx), id); statusErr == nil {
+		fmt.Fprintf(os.Stderr, "embedded %d/%d inputs, %d failed, %d tokens used\n",
+			progress.Done, progress.Total, progress.Failed, progress.Usage.TotalTokens)
+		if progress.Pending > 0 {
+			fmt.Fprintf(os.Stderr, "run again with -resume to embed the remaining %d inputs\n", progress.Pending)
+		}
+	}
+
+	return err
+}
+
+func readEmbedInputs(path string) ([]embedInput, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open input: %w", err)
+	}
+	defer f.Close()
+
+	var inputs []embedInput
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64<<10), 64<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var in embedInput
+		if err := json.Unmarshal(scanner.Bytes(), &in); err != nil {
+			return nil, fmt.Errorf("input line %d: %w", line, err)
+		}
+		if in.Text == "" && in.Image == "" && in.PDF == "" {
+			return nil, fmt.Errorf("input line %d: one of text, image or pdf is required", line)
+		}
+		inputs = append(inputs, in)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read input: %w", err)
+	}
+
+	return inputs, nil
+}
+
+// showProgress redraws a progress bar on stderr until the returned function is called.
+func showProgress(done *atomic.Int64, total int) func() {
+	const width = 30
+	draw := func() {
+		n := int(done.Load())
+		filled := 0
+		if total > 0 {
+			filled = min(n*width/total, width)
+		}
+		fmt.Fprintf(os.Stderr, "\r[%s%s] %d/%d", strings.Repeat("=", filled), strings.Repeat(" ", width-filled), n, total)
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		ticker := time.NewTicker(200 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			draw()
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	})
+
+	return func() {
+		close(stop)
+		wg.Wait()
+		draw()
+		fmt.Fprintln(os.Stderr)
+	}
+}
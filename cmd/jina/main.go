@@ -0,0 +1,54 @@
+// Command jina is a command line companion for the gojina client library.
+//
+// Usage:
+//
+//	jina <command> [flags]
+//
+// Commands:
+//
+//	doctor    check the API key, endpoint reachability and EU routing
+//	embed     embed a JSONL file of inputs into a JSONL file of vectors
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "doctor":
+		err = runDoctor(os.Args[2:])
+	case "embed":
+		err = runEmbed(os.Args[2:])
+	case "help", "-h", "--help":
+		usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprint(os.Stderr, `Usage: jina <command> [flags]
+
+Commands:
+  doctor    check the API key, endpoint reachability and EU routing
+  embed     embed a JSONL file of inputs into a JSONL file of vectors
+
+The API key is read from the JINA_API_KEY environment variable.
+`)
+}
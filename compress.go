@@ -0,0 +1,57 @@
+package jina
+
+import (
+	"compress/gzip"
+	"fmt"
+	"net/http"
+)
+
+// WithRequestCompression gzips the JSON bodies of at least threshold bytes, e.g. embedding batches
+// of thousands of inputs or base64 PDFs, and sends them with Content-Encoding: gzip. Bodies that do
+// not shrink are sent as is. Limits apply to the uncompressed body. A threshold of 0 or less disables
+// compression, the default.
+func WithRequestCompression(threshold int) Option {
+	return func(cfg *config) {
+		cfg.CompressionThreshold = threshold
+	}
+}
+
+// compressRequestBody gzips a body past the configured threshold and marks the request accordingly.
+func (cl *Client) compressRequestBody(req *http.Request, body *requestBody) error {
+	threshold := cl.cfg.CompressionThreshold
+	if threshold <= 0 || body.len() < threshold {
+		return nil
+	}
+	compressed, err := body.compress()
+	if err != nil {
+		return fmt.Errorf("failed to compress request: %w", err)
+	}
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	return nil
+}
+
+// compress gzips the body in place, reporting false and keeping it if that does not shrink it.
+// It must be called before any reader of the body is handed out.
+func (b *requestBody) compress() (bool, error) {
+	buf := getBuffer()
+	zw := gzip.NewWriter(buf)
+	if _, err := zw.Write(b.buf.Bytes()); err != nil {
+		putBuffer(buf)
+		return false, err
+	}
+	if err := zw.Close(); err != nil {
+		putBuffer(buf)
+		return false, err
+	}
+	if buf.Len() >= b.buf.Len() {
+		putBuffer(buf)
+		return false, nil
+	}
+
+	putBuffer(b.buf)
+	b.buf = buf
+	return true, nil
+}
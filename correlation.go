@@ -0,0 +1,154 @@
+package jina
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// CorrelationHeader is the request header carrying the correlation ID of a call.
+const CorrelationHeader = "X-Request-Id"
+
+type correlationIDContextKey struct{}
+
+// ContextWithCorrelationID returns a context making calls send id as their correlation ID, e.g. the
+// ID of the incoming request being served, so logs of both services can be joined. Calls without one
+// get a random correlation ID.
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDContextKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID set with ContextWithCorrelationID.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIDContextKey{}).(string)
+	return id, ok && id != ""
+}
+
+// CorrelationID returns the correlation ID of the call that failed with err, or "" if err did not
+// come from a call to the API. Include it in support tickets.
+func CorrelationID(err error) string {
+	var correlated *correlatedError
+	if errors.As(err, &correlated) {
+		return correlated.id
+	}
+
+	return ""
+}
+
+// correlatedError tags an error with the correlation ID of the failed call.
+type correlatedError struct {
+	id  string
+	err error
+}
+
+func (e *correlatedError) Error() string {
+	return fmt.Sprintf("%v (correlation ID %s)", e.err, e.id)
+}
+
+func (e *correlatedError) Unwrap() error {
+	return e.err
+}
+
+// ResponseMeta describes the HTTP exchange of a call, see WithResponseMeta.
+type ResponseMeta struct {
+	CorrelationID string
+	StatusCode    int // Zero when no response was received
+	Header        http.Header
+}
+
+// Meta describes the HTTP exchange a response was received in, e.g. to quote the request ID in a
+// support ticket or to slow down as the rate limit runs out. See WithResponseMeta to capture it for
+// failed calls too.
+type Meta struct {
+	// RequestID identifies the call, the X-Request-Id returned by the API or else the one sent.
+	RequestID string
+
+	// RateLimit holds the X-RateLimit headers of the response, zero if it carried none.
+	RateLimit RateLimit
+
+	// Latency is the time from sending the call until the response headers arrived, retries included.
+	Latency time.Duration
+
+	// Header holds all response headers, e.g. model version headers of the endpoint.
+	Header http.Header
+
+	// Raw is the response body as received, only kept with WithRawResponses.
+	Raw []byte
+}
+
+// WithRawResponses keeps the body of every response in its Meta.Raw, so fields the response types do
+// not model yet can be read without waiting for a release of this package, see Meta.DecodeRaw.
+// This doubles the memory held by responses, including the vectors of EmbeddingsEach, use
+// WithRawResponse to capture the body of single calls instead.
+func WithRawResponses() Option {
+	return func(cfg *config) {
+		cfg.RawResponses = true
+	}
+}
+
+// DecodeRaw unmarshals the raw JSON response into v, e.g. a struct holding only the fields missing
+// from the response type. It fails when the response was not kept, see WithRawResponses.
+func (m Meta) DecodeRaw(v any) error {
+	if m.Raw == nil {
+		return errors.New("raw response not kept, see WithRawResponses")
+	}
+
+	return json.Unmarshal(m.Raw, v)
+}
+
+// WithResponseMeta stores the correlation ID, status and headers of the call's response in dst.
+// dst is filled for failed calls too, as far as the call got.
+func WithResponseMeta(dst *ResponseMeta) CallOption {
+	return func(call *callConfig) {
+		call.responseMeta = dst
+	}
+}
+
+// setCorrelationID sets the correlation header of req from its context or a new random ID.
+func (call *callConfig) setCorrelationID(req *http.Request) {
+	id, ok := CorrelationIDFromContext(req.Context())
+	if !ok {
+		id = newCorrelationID()
+	}
+	req.Header.Set(CorrelationHeader, id)
+
+	call.correlationID = id
+	if call.responseMeta != nil {
+		*call.responseMeta = ResponseMeta{CorrelationID: id}
+	}
+}
+
+func (call *callConfig) captureMeta(resp *http.Response, now time.Time) {
+	call.statusCode = resp.StatusCode
+	call.meta = Meta{
+		RequestID: cmp.Or(resp.Header.Get(CorrelationHeader), call.correlationID),
+		RateLimit: parseRateLimit(resp.Header, now),
+		Latency:   now.Sub(call.started),
+		Header:    resp.Header.Clone(),
+	}
+	if call.responseMeta != nil {
+		call.responseMeta.StatusCode = resp.StatusCode
+		call.responseMeta.Header = resp.Header.Clone()
+	}
+}
+
+// correlate tags err with the correlation ID of the call.
+func (call *callConfig) correlate(err error) error {
+	if err == nil || call.correlationID == "" {
+		return err
+	}
+
+	return &correlatedError{id: call.correlationID, err: err}
+}
+
+func newCorrelationID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -4,8 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 const DeepSearchModelDefault = "jina-deepsearch-v1"
@@ -40,6 +38,12 @@ type DeepSearchRequest struct {
 
 	// BoostHostnames boosts specific hostnames in the search results.
 	BoostHostnames []string `json:"boost_hostnames,omitempty"`
+
+	// OnlyHostnames restricts the search to these hostnames.
+	OnlyHostnames []string `json:"only_hostnames,omitempty"`
+
+	// BadHostnames excludes these hostnames from the search results.
+	BadHostnames []string `json:"bad_hostnames,omitempty"`
 }
 
 type DeepSearchResponseFormat struct {
@@ -55,6 +59,19 @@ type DeepSearchResponse struct {
 	Created int64              `json:"created"`
 	Model   string             `json:"model"`
 	Choices []DeepSearchChoice `json:"choices"`
+	Usage   Usage              `json:"usage"`
+	Meta    Meta               `json:"-"` // Set on responses, not on streamed chunks
+
+	// Event is the server-sent event name of a streamed chunk, empty for unnamed events.
+	Event string `json:"-"`
+
+	// EventID is the last server-sent event ID of the stream at a streamed chunk, empty if none was sent.
+	EventID string `json:"-"`
+
+	// URLs found and read during the research, reported with the final chunk or response.
+	VisitedURLs []string `json:"visitedURLs,omitempty"`
+	ReadURLs    []string `json:"readURLs,omitempty"`
+	NumURLs     int      `json:"numURLs,omitempty"`
 }
 
 type DeepSearchChoice struct {
@@ -62,6 +79,8 @@ type DeepSearchChoice struct {
 	Delta struct {
 		Content string `json:"content"`
 		Type    string `json:"type"`
+		URL     string `json:"url,omitempty"`   // URL being visited, on search activity deltas
+		Query   string `json:"query,omitempty"` // Query being searched, on search activity deltas
 	} `json:"delta"`
 	Message      VLMMessage `json:"message"`
 	Logprobs     any        `json:"logprobs"`
@@ -69,85 +88,64 @@ type DeepSearchChoice struct {
 }
 
 // DeepSearch calls the Jina DeepSearch API for comprehensive investigation.
-func (cl *Client) DeepSearch(ctx context.Context, req DeepSearchRequest) (*DeepSearchResponse, error) {
-	url := "https://deepsearch.jina.ai/v1/chat/completions"
-
+func (cl *Client) DeepSearch(ctx context.Context, req DeepSearchRequest, opts ...CallOption) (*DeepSearchResponse, error) {
 	if req.Model == "" {
-		req.Model = DeepSearchModelDefault
+		req.Model = cl.cfg.DefaultDeepSearchModel
 	}
 	req.Stream = false // Force stream to false for synchronous call
-
-	jsonData, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "application/json")
-	if cl.cfg.APIKey != "" {
-		httpReq.Header.Set("Authorization", "Bearer "+cl.cfg.APIKey)
-	}
-
-	client := &http.Client{}
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	if err := cl.cfg.Limits.checkMessages(req.Messages); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		var errResp map[string]interface{}
-		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil {
-			return nil, fmt.Errorf("API error: %v", errResp)
-		}
-		return nil, fmt.Errorf("API error with status code: %d", resp.StatusCode)
-	}
+	return withModelFallback(cl, EndpointDeepSearch, req.Model, func(model string) (*DeepSearchResponse, error) {
+		req.Model = model
 
-	var result DeepSearchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+		var result DeepSearchResponse
+		if err := cl.postJSON(ctx, EndpointDeepSearch, req, &result, newCallConfig(opts)); err != nil {
+			return nil, err
+		}
+		if result.Model == "" {
+			result.Model = model
+		}
+		cl.reportUsage(ctx, EndpointDeepSearch, model, result.Usage)
 
-	return &result, nil
+		return &result, nil
+	})
 }
 
 // DeepSearchStream calls the Jina DeepSearch API with streaming enabled.
 // The callback function is invoked for each chunk of the response.
-func (cl *Client) DeepSearchStream(ctx context.Context, req DeepSearchRequest, callback func(*DeepSearchResponse) error) error {
-	url := "https://deepsearch.jina.ai/v1/chat/completions"
-
+func (cl *Client) DeepSearchStream(ctx context.Context, req DeepSearchRequest, callback func(*DeepSearchResponse) error, opts ...CallOption) error {
 	if req.Model == "" {
-		req.Model = DeepSearchModelDefault
+		req.Model = cl.cfg.DefaultDeepSearchModel
 	}
 	req.Stream = true
-
-	jsonData, err := json.Marshal(req)
-	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
+	if err := cl.cfg.Limits.checkMessages(req.Messages); err != nil {
+		return err
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "text/event-stream")
-	if cl.cfg.APIKey != "" {
-		httpReq.Header.Set("Authorization", "Bearer "+cl.cfg.APIKey)
-	}
-
-	return cl.doStream(httpReq, func(data []byte) error {
-		//fmt.Println("data: ", string(data))
+	call := newCallConfig(opts)
+	tracker := &deepSearchTracker{notify: call.deepSearchActivity}
+	index := 0
+	// Streams may repeat the cumulative usage on several chunks, only the last one is reported
+	err := cl.postStream(ctx, EndpointDeepSearch, req, call, func(event streamEvent) error {
 		var chunk DeepSearchResponse
-		if err := json.Unmarshal(data, &chunk); err != nil {
-			return fmt.Errorf("failed to unmarshal chunk: %w", err)
+		index++
+		if err := json.Unmarshal(event.Data, &chunk); err != nil {
+			return &StreamChunkError{Endpoint: EndpointDeepSearch, Index: index - 1, Event: event.Name, Data: bytes.Clone(event.Data), Err: err}
 		}
+		chunk.Event = event.Name
+		chunk.EventID = event.ID
+		if chunk.Usage.TotalTokens > 0 {
+			call.usage = chunk.Usage
+		}
+		tracker.observe(&chunk)
 		return callback(&chunk)
 	})
+	if err != nil {
+		return err
+	}
+	cl.reportUsage(ctx, EndpointDeepSearch, req.Model, call.usage)
+
+	return nil
 }
@@ -0,0 +1,197 @@
+// Package deepsearch provides a fluent builder for validated jina.DeepSearchRequest values.
+//
+//	req, err := deepsearch.New("What changed in the EU AI Act in 2025?").
+//		WithBudget(200_000).
+//		BoostHosts("europa.eu").
+//		BadHosts("example.com").
+//		Build()
+package deepsearch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fritzkeyzer/gojina"
+)
+
+// Builder builds a jina.DeepSearchRequest. Setters record invalid values, which are reported by Build.
+type Builder struct {
+	req      jina.DeepSearchRequest
+	system   string
+	history  []jina.VLMMessage
+	question string
+	errs     []error
+}
+
+// New starts a request asking question. The question is sent as the final user message,
+// after the system prompt and any history.
+func New(question string) *Builder {
+	return &Builder{question: question}
+}
+
+// Model sets the model. Defaults to the client's default DeepSearch model.
+func (b *Builder) Model(model string) *Builder {
+	b.req.Model = model
+	return b
+}
+
+// System sets the system prompt, sent as the first message.
+func (b *Builder) System(prompt string) *Builder {
+	b.system = prompt
+	return b
+}
+
+// User appends a previous user turn to the conversation history.
+func (b *Builder) User(content string) *Builder {
+	b.history = append(b.history, jina.NewVLMMessage("user", content))
+	return b
+}
+
+// Assistant appends a previous assistant answer to the conversation history.
+func (b *Builder) Assistant(content string) *Builder {
+	b.history = append(b.history, jina.NewVLMMessage("assistant", content))
+	return b
+}
+
+// WithBudget sets the maximum number of tokens spent on the DeepSearch process.
+func (b *Builder) WithBudget(tokens int) *Builder {
+	if tokens < 0 {
+		b.errs = append(b.errs, fmt.Errorf("budget must not be negative, got %d", tokens))
+	}
+	b.req.BudgetTokens = tokens
+	return b
+}
+
+// ReasoningEffort sets the reasoning effort: low, medium or high.
+func (b *Builder) ReasoningEffort(effort string) *Builder {
+	if !slices.Contains([]string{"low", "medium", "high"}, effort) {
+		b.errs = append(b.errs, fmt.Errorf("reasoning effort must be low, medium or high, got %q", effort))
+	}
+	b.req.ReasoningEffort = effort
+	return b
+}
+
+// MaxAttempts sets the maximum number of attempts at solving the question.
+func (b *Builder) MaxAttempts(n int) *Builder {
+	if n < 0 {
+		b.errs = append(b.errs, fmt.Errorf("max attempts must not be negative, got %d", n))
+	}
+	b.req.MaxAttempts = n
+	return b
+}
+
+// NoDirectAnswer forces further search steps even for trivial questions.
+func (b *Builder) NoDirectAnswer() *Builder {
+	b.req.NoDirectAnswer = true
+	return b
+}
+
+// MaxReturnedURLs sets the maximum number of URLs cited in the answer.
+func (b *Builder) MaxReturnedURLs(n int) *Builder {
+	if n < 0 {
+		b.errs = append(b.errs, fmt.Errorf("max returned URLs must not be negative, got %d", n))
+	}
+	b.req.MaxReturnedURLs = n
+	return b
+}
+
+// BoostHosts boosts results from the hostnames.
+func (b *Builder) BoostHosts(hosts ...string) *Builder {
+	b.req.BoostHostnames = b.hosts(b.req.BoostHostnames, hosts)
+	return b
+}
+
+// OnlyHosts restricts the search to the hostnames.
+func (b *Builder) OnlyHosts(hosts ...string) *Builder {
+	b.req.OnlyHostnames = b.hosts(b.req.OnlyHostnames, hosts)
+	return b
+}
+
+// BadHosts excludes results from the hostnames.
+func (b *Builder) BadHosts(hosts ...string) *Builder {
+	b.req.BadHostnames = b.hosts(b.req.BadHostnames, hosts)
+	return b
+}
+
+// Schema requests a structured answer following the JSON schema. schema may be a JSON document
+// ([]byte, json.RawMessage or string) or any value marshaling to one.
+func (b *Builder) Schema(schema any) *Builder {
+	var raw []byte
+	switch s := schema.(type) {
+	case json.RawMessage:
+		raw = s
+	case []byte:
+		raw = s
+	case string:
+		raw = []byte(s)
+	default:
+		var err error
+		if raw, err = json.Marshal(schema); err != nil {
+			b.errs = append(b.errs, fmt.Errorf("marshal schema: %w", err))
+			return b
+		}
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal(raw, &object); err != nil {
+		b.errs = append(b.errs, fmt.Errorf("schema must be a JSON object: %w", err))
+		return b
+	}
+	b.req.ResponseFormat = &jina.DeepSearchResponseFormat{Type: "json_schema", JSONSchema: raw}
+	return b
+}
+
+// Build validates the request and returns it.
+func (b *Builder) Build() (jina.DeepSearchRequest, error) {
+	errs := slices.Clone(b.errs)
+	if strings.TrimSpace(b.question) == "" {
+		errs = append(errs, errors.New("question is required"))
+	}
+	for _, host := range b.req.BadHostnames {
+		if slices.Contains(b.req.OnlyHostnames, host) {
+			errs = append(errs, fmt.Errorf("host %q is both allowed and excluded", host))
+		}
+		if slices.Contains(b.req.BoostHostnames, host) {
+			errs = append(errs, fmt.Errorf("host %q is both boosted and excluded", host))
+		}
+	}
+	if len(b.req.OnlyHostnames) > 0 {
+		for _, host := range b.req.BoostHostnames {
+			if !slices.Contains(b.req.OnlyHostnames, host) {
+				errs = append(errs, fmt.Errorf("boosted host %q is not in the allowed hosts", host))
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return jina.DeepSearchRequest{}, err
+	}
+
+	req := b.req
+	req.Messages = nil
+	if b.system != "" {
+		req.Messages = append(req.Messages, jina.NewVLMMessage("system", b.system))
+	}
+	req.Messages = append(req.Messages, b.history...)
+	req.Messages = append(req.Messages, jina.NewVLMMessage("user", b.question))
+
+	return req, nil
+}
+
+// hosts validates and appends hostnames. Hostnames are given without scheme or path.
+func (b *Builder) hosts(dst, hosts []string) []string {
+	for _, host := range hosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host == "" || strings.ContainsAny(host, "/: ") {
+			b.errs = append(b.errs, fmt.Errorf("invalid hostname %q: expected a bare hostname like example.com", host))
+			continue
+		}
+		if !slices.Contains(dst, host) {
+			dst = append(dst, host)
+		}
+	}
+
+	return dst
+}
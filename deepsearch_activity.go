@@ -0,0 +1,61 @@
+package jina
+
+import "slices"
+
+// DeepSearchActivity summarizes the research done so far by a streaming DeepSearch.
+type DeepSearchActivity struct {
+	Queries     []string // Search queries executed, in order
+	VisitedURLs []string // URLs found in search results
+	ReadURLs    []string // URLs whose content was read
+	NumURLs     int      // URLs considered, as last reported by the API
+}
+
+// WithDeepSearchActivity calls fn from DeepSearchStream each time a chunk reports new search queries
+// or URLs, so dashboards can show what is being searched and read in real time. fn receives a
+// snapshot it may retain and runs before the chunk's callback.
+func WithDeepSearchActivity(fn func(DeepSearchActivity)) CallOption {
+	return func(call *callConfig) {
+		call.deepSearchActivity = fn
+	}
+}
+
+// deepSearchTracker accumulates the activity reported by the chunks of a stream.
+type deepSearchTracker struct {
+	notify   func(DeepSearchActivity)
+	activity DeepSearchActivity
+}
+
+func (t *deepSearchTracker) observe(chunk *DeepSearchResponse) {
+	if t.notify == nil {
+		return
+	}
+
+	changed := false
+	add := func(list *[]string, values ...string) {
+		for _, value := range values {
+			if value != "" && !slices.Contains(*list, value) {
+				*list = append(*list, value)
+				changed = true
+			}
+		}
+	}
+	for _, choice := range chunk.Choices {
+		add(&t.activity.Queries, choice.Delta.Query)
+		add(&t.activity.VisitedURLs, choice.Delta.URL)
+	}
+	add(&t.activity.VisitedURLs, chunk.VisitedURLs...)
+	add(&t.activity.ReadURLs, chunk.ReadURLs...)
+	if chunk.NumURLs > 0 && chunk.NumURLs != t.activity.NumURLs {
+		t.activity.NumURLs = chunk.NumURLs
+		changed = true
+	}
+
+	if changed {
+		t.notify(DeepSearchActivity{
+			Queries:     slices.Clone(t.activity.Queries),
+			VisitedURLs: slices.Clone(t.activity.VisitedURLs),
+			ReadURLs:    slices.Clone(t.activity.ReadURLs),
+			NumURLs:     t.activity.NumURLs,
+		})
+	}
+}
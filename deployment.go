@@ -0,0 +1,70 @@
+package jina
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrNotDeployed is returned for calls to endpoints the configured Deployment does not provide.
+var ErrNotDeployed = errors.New("endpoint not available in deployment")
+
+// Deployment describes where the Jina APIs are hosted and how they authenticate, e.g. a cloud
+// marketplace or private deployment instead of the public API. All request and response types
+// are shared with the public API.
+type Deployment struct {
+	Name string
+
+	// URLs maps every endpoint provided by the deployment to its URL. Calls to other endpoints fail
+	// with ErrNotDeployed rather than falling back to the public API.
+	URLs map[Endpoint]string
+
+	// EUURLs maps the endpoints with an EU-hosted variant, used with WithEUCompliance.
+	EUURLs map[Endpoint]string
+
+	// Authenticator authenticates requests to the deployment. Nil keeps the client's authenticator.
+	Authenticator Authenticator
+}
+
+// PublicDeployment is the public Jina API, used when no deployment is configured.
+var PublicDeployment = Deployment{
+	Name:   "jina",
+	URLs:   defaultEndpointURLs,
+	EUURLs: euEndpointURLs,
+}
+
+// modelEndpointPaths are the paths of the model APIs hosted by marketplace and private deployments.
+var modelEndpointPaths = map[Endpoint]string{
+	EndpointEmbeddings: "/v1/embeddings",
+	EndpointRerank:     "/v1/rerank",
+	EndpointClassify:   "/v1/classify",
+	EndpointTrain:      "/v1/train",
+}
+
+// NewDeployment returns a deployment serving the model APIs (embeddings, rerank, classify and train)
+// under baseURL, as marketplace and private deployments do, authenticated with auth. For example,
+// for a deployment expecting the key in an api-key header:
+//
+//	jina.WithDeployment(jina.NewDeployment("azure", "https://my-endpoint.example.com", jina.HeaderAuth("api-key")))
+//
+// Client.Call resolves relative paths against baseURL too. Add further endpoints to the returned URLs
+// if the deployment hosts them.
+func NewDeployment(name, baseURL string, auth Authenticator) Deployment {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	urls := make(map[Endpoint]string, len(modelEndpointPaths)+1)
+	for endpoint, path := range modelEndpointPaths {
+		urls[endpoint] = baseURL + path
+	}
+	urls[EndpointCustom] = baseURL + "/"
+
+	return Deployment{Name: name, URLs: urls, Authenticator: auth}
+}
+
+// WithDeployment sends all calls to the given deployment instead of the public API.
+func WithDeployment(deployment Deployment) Option {
+	return func(cfg *config) {
+		cfg.Deployment = &deployment
+		if deployment.Authenticator != nil {
+			cfg.Authenticator = deployment.Authenticator
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package jina
+
+// Device describes the browser the Reader emulates: its viewport, user agent and locale.
+// Many sites serve different content to mobile and desktop browsers, see ReaderRequest.EmulateDevice.
+type Device struct {
+	Viewport  Viewport
+	UserAgent string
+	Locale    string
+}
+
+var (
+	// DeviceMobile emulates a recent iPhone.
+	DeviceMobile = Device{
+		Viewport:  Viewport{Width: 390, Height: 844, DeviceScaleFactor: 3, IsMobile: true, HasTouch: true},
+		UserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1",
+		Locale:    "en-US",
+	}
+
+	// DeviceTablet emulates a recent iPad.
+	DeviceTablet = Device{
+		Viewport:  Viewport{Width: 820, Height: 1180, DeviceScaleFactor: 2, IsMobile: true, HasTouch: true},
+		UserAgent: "Mozilla/5.0 (iPad; CPU OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1",
+		Locale:    "en-US",
+	}
+
+	// DeviceDesktop emulates Chrome on a 1080p Windows desktop.
+	DeviceDesktop = Device{
+		Viewport:  Viewport{Width: 1920, Height: 1080, DeviceScaleFactor: 1},
+		UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36",
+		Locale:    "en-US",
+	}
+)
+
+// EmulateDevice sets the Viewport, UserAgent and BrowserLocale of the request from device.
+// A BrowserLocale already set on the request is kept, so presets can be combined with any locale.
+func (r *ReaderRequest) EmulateDevice(device Device) {
+	viewport := device.Viewport
+	r.Viewport = &viewport
+	r.UserAgent = device.UserAgent
+	if r.BrowserLocale == "" {
+		r.BrowserLocale = device.Locale
+	}
+}
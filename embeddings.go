@@ -1,11 +1,8 @@
 package jina
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 type EmbeddingModel string
@@ -49,7 +46,7 @@ const (
 )
 
 type EmbeddingsRequest struct {
-	// Model is the identifier of the model to use.
+	// Model is the identifier of the model to use. Defaults to the client's WithDefaultEmbeddingModel.
 	Model EmbeddingModel `json:"model"`
 
 	// Input is the array of input strings or objects to be embedded.
@@ -113,8 +110,13 @@ func NewEmbeddingInputPDF(pdfURL string) EmbeddingInput {
 }
 
 type EmbeddingsResponse struct {
+	Model string          `json:"model"`
 	Data  []EmbeddingData `json:"data"`
 	Usage Usage           `json:"usage"`
+	Meta  Meta            `json:"-"`
+
+	// Truncated lists the inputs shortened client-side, see WithInputTruncation.
+	Truncated []TruncatedInput `json:"-"`
 }
 
 type EmbeddingData struct {
@@ -130,49 +132,39 @@ type Usage struct {
 }
 
 // Embeddings calls the Jina Embeddings API.
-func (cl *Client) Embeddings(ctx context.Context, req EmbeddingsRequest) (*EmbeddingsResponse, error) {
-	url := "https://api.jina.ai/v1/embeddings"
-
-	jsonData, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+func (cl *Client) Embeddings(ctx context.Context, req EmbeddingsRequest, opts ...CallOption) (*EmbeddingsResponse, error) {
+	if req.Model == "" {
+		req.Model = cl.cfg.DefaultEmbeddingModel
 	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "application/json")
-	if cl.cfg.APIKey != "" {
-		httpReq.Header.Set("Authorization", "Bearer "+cl.cfg.APIKey)
-	}
-
-	resp, err := cl.do(httpReq)
+	truncated, windows, err := cl.truncateInputs(ctx, &req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		var errResp map[string]interface{}
-		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil {
-			return nil, fmt.Errorf("API error: %v", errResp)
-		}
-		return nil, fmt.Errorf("API error with status code: %d", resp.StatusCode)
+	if err := cl.cfg.Limits.checkEmbeddings(req); err != nil {
+		return nil, err
 	}
 
-	var result EmbeddingsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+	return withModelFallback(cl, EndpointEmbeddings, string(req.Model), func(model string) (*EmbeddingsResponse, error) {
+		req.Model = EmbeddingModel(model)
 
-	return &result, nil
-}
+		var result EmbeddingsResponse
+		if err := cl.postJSON(ctx, EndpointEmbeddings, req, &result, newCallConfig(opts)); err != nil {
+			return nil, err
+		}
+		if result.Model == "" {
+			result.Model = model
+		}
+		cl.reportUsage(ctx, EndpointEmbeddings, model, result.Usage)
+		result.Truncated = truncated
+		if windows != nil {
+			if err := poolWindows(&result, windows); err != nil {
+				return nil, err
+			}
+		}
+		if err := cl.normalize(&result); err != nil {
+			return nil, err
+		}
 
-// Helper method to execute requests (can be moved to client.go later)
-func (cl *Client) do(req *http.Request) (*http.Response, error) {
-	client := &http.Client{}
-	return client.Do(req)
+		return &result, nil
+	})
 }
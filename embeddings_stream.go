@@ -0,0 +1,159 @@
+package jina
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// ErrWindowsNotStreamed is returned by EmbeddingsEach when TruncationWindows split an input, since
+// pooling its windows needs every window's embedding at once.
+var ErrWindowsNotStreamed = errors.New("windowed inputs cannot be streamed")
+
+// EmbeddingsEach calls the Jina Embeddings API like Embeddings, but decodes the response incrementally
+// and passes every embedding to fn as soon as it is read off the wire, so peak memory stays at a single
+// vector instead of the whole batch. fn is called in response order, which is normally input order.
+//
+// The returned response carries the model, usage and truncations, but no Data. An error returned by
+// fn stops decoding and is returned.
+func (cl *Client) EmbeddingsEach(ctx context.Context, req EmbeddingsRequest, fn func(EmbeddingData) error, opts ...CallOption) (*EmbeddingsResponse, error) {
+	if req.Model == "" {
+		req.Model = cl.cfg.DefaultEmbeddingModel
+	}
+	truncated, windows, err := cl.truncateInputs(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	if windows != nil {
+		return nil, ErrWindowsNotStreamed
+	}
+	if err := cl.cfg.Limits.checkEmbeddings(req); err != nil {
+		return nil, err
+	}
+
+	return withModelFallback(cl, EndpointEmbeddings, string(req.Model), func(model string) (*EmbeddingsResponse, error) {
+		req.Model = EmbeddingModel(model)
+
+		var result EmbeddingsResponse
+		call := newCallConfig(opts)
+		err := cl.postJSONStreaming(ctx, EndpointEmbeddings, req, call, func(body io.Reader) error {
+			if err := cl.decodeEmbeddings(body, &result, req.Dimensions, fn); err != nil {
+				return err
+			}
+			call.usage = result.Usage
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if result.Model == "" {
+			result.Model = model
+		}
+		result.Meta = call.meta
+		cl.reportUsage(ctx, EndpointEmbeddings, model, result.Usage)
+		result.Truncated = truncated
+
+		return &result, nil
+	})
+}
+
+// errStopEmbeddings stops decoding when the consumer of EmbeddingsSeq breaks out of its loop.
+var errStopEmbeddings = errors.New("embeddings iteration stopped")
+
+// EmbeddingsSeq returns an iterator over the embeddings of the request, decoded incrementally like
+// EmbeddingsEach, for batch pipelines written as range loops:
+//
+//	for data, err := range client.EmbeddingsSeq(ctx, req) {
+//		if err != nil {
+//			return err
+//		}
+//		store(data.Index, data.Embedding)
+//	}
+//
+// A failed call yields a single error, and an error while decoding ends the iteration with it after
+// the embeddings read before. Breaking out of the loop closes the response. The usage of iterations
+// run to the end is reported to the usage hooks and Client.Usage, see EmbeddingsEach for the response.
+func (cl *Client) EmbeddingsSeq(ctx context.Context, req EmbeddingsRequest, opts ...CallOption) iter.Seq2[EmbeddingData, error] {
+	return func(yield func(EmbeddingData, error) bool) {
+		_, err := cl.EmbeddingsEach(ctx, req, func(data EmbeddingData) error {
+			if !yield(data, nil) {
+				return errStopEmbeddings
+			}
+			return nil
+		}, opts...)
+		if err != nil && !errors.Is(err, errStopEmbeddings) {
+			yield(EmbeddingData{}, err)
+		}
+	}
+}
+
+// decodeEmbeddings decodes an embeddings response into resp, passing the elements of data to fn
+// one at a time instead of collecting them. dims, if known, sizes the vectors up front.
+func (cl *Client) decodeEmbeddings(body io.Reader, resp *EmbeddingsResponse, dims int, fn func(EmbeddingData) error) error {
+	dec := json.NewDecoder(body)
+	if err := expectResponseDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		switch tok {
+		case "model":
+			err = dec.Decode(&resp.Model)
+		case "usage":
+			err = dec.Decode(&resp.Usage)
+		case "data":
+			err = cl.decodeEmbeddingData(dec, dims, fn)
+		default:
+			err = dec.Decode(new(json.RawMessage))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return expectResponseDelim(dec, '}')
+}
+
+// decodeEmbeddingData decodes the vectors into slices sized like the previous one, so each takes a
+// single allocation instead of growing element by element.
+func (cl *Client) decodeEmbeddingData(dec *json.Decoder, dims int, fn func(EmbeddingData) error) error {
+	if err := expectResponseDelim(dec, '['); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		data := EmbeddingData{Embedding: make([]float32, 0, dims)}
+		if err := dec.Decode(&data); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		dims = len(data.Embedding)
+		if err := cl.normalizeEmbedding(&data); err != nil {
+			return err
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+
+	return expectResponseDelim(dec, ']')
+}
+
+func expectResponseDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if tok != delim {
+		return fmt.Errorf("failed to decode response: expected %v, got %v", delim, tok)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package jina
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// Endpoint identifies a Jina API endpoint family.
+type Endpoint string
+
+const (
+	EndpointEmbeddings Endpoint = "embeddings"
+	EndpointRerank     Endpoint = "rerank"
+	EndpointClassify   Endpoint = "classify"
+	EndpointTrain      Endpoint = "train"
+	EndpointSegment    Endpoint = "segment"
+	EndpointReader     Endpoint = "reader"
+	EndpointSearch     Endpoint = "search"
+	EndpointDeepSearch Endpoint = "deepsearch"
+	EndpointVLM        Endpoint = "vlm"
+)
+
+// Endpoints lists every endpoint family supported by the client.
+var Endpoints = []Endpoint{
+	EndpointEmbeddings,
+	EndpointRerank,
+	EndpointClassify,
+	EndpointTrain,
+	EndpointSegment,
+	EndpointReader,
+	EndpointSearch,
+	EndpointDeepSearch,
+	EndpointVLM,
+}
+
+var defaultEndpointURLs = map[Endpoint]string{
+	EndpointEmbeddings: "https://api.jina.ai/v1/embeddings",
+	EndpointRerank:     "https://api.jina.ai/v1/rerank",
+	EndpointClassify:   "https://api.jina.ai/v1/classify",
+	EndpointTrain:      "https://api.jina.ai/v1/train",
+	EndpointSegment:    "https://segment.jina.ai/",
+	EndpointReader:     "https://r.jina.ai/",
+	EndpointSearch:     "https://s.jina.ai/",
+	EndpointDeepSearch: "https://deepsearch.jina.ai/v1/chat/completions",
+	EndpointVLM:        "https://api-beta-vlm.jina.ai/v1/chat/completions",
+	EndpointCustom:     "https://api.jina.ai/", // Base of the relative paths of Client.Call
+}
+
+// euEndpointURLs holds the EU-hosted variants of the endpoints that have one.
+// Endpoints missing here fail with ErrNoEUEndpoint when EU compliance is enabled.
+// DeepSearch and VLM are only served globally.
+var euEndpointURLs = map[Endpoint]string{
+	EndpointEmbeddings: "https://eu.api.jina.ai/v1/embeddings",
+	EndpointRerank:     "https://eu.api.jina.ai/v1/rerank",
+	EndpointClassify:   "https://eu.api.jina.ai/v1/classify",
+	EndpointTrain:      "https://eu.api.jina.ai/v1/train",
+	EndpointSegment:    "https://eu.segment.jina.ai/",
+	EndpointReader:     "https://eu.r.jina.ai/",
+	EndpointSearch:     "https://eu.s.jina.ai/",
+	EndpointCustom:     "https://eu.api.jina.ai/",
+}
+
+// ErrNoEUEndpoint is returned when EU compliance is enabled for an endpoint without an EU-hosted
+// variant, rather than silently sending the data to global infrastructure.
+var ErrNoEUEndpoint = errors.New("no EU endpoint available")
+
+// NoEUEndpointError reports the endpoint a call with EU compliance enabled had no EU-hosted variant for.
+// It unwraps to ErrNoEUEndpoint.
+type NoEUEndpointError struct {
+	Endpoint Endpoint
+}
+
+func (e *NoEUEndpointError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Endpoint, ErrNoEUEndpoint)
+}
+
+func (e *NoEUEndpointError) Unwrap() error {
+	return ErrNoEUEndpoint
+}
+
+// endpointURL resolves the URL of an endpoint, using its EU variant when EU compliance is enabled.
+func (cl *Client) endpointURL(endpoint Endpoint) (string, error) {
+	return cl.resolveEndpointURL(endpoint, cl.cfg.EUCompliance)
+}
+
+// resolveEndpointURL resolves the URL of an endpoint in the configured deployment.
+func (cl *Client) resolveEndpointURL(endpoint Endpoint, eu bool) (string, error) {
+	urls, euURLs := cl.endpointURLs()
+	if eu {
+		url, ok := euURLs[endpoint]
+		if !ok {
+			return "", &NoEUEndpointError{Endpoint: endpoint}
+		}
+		return url, nil
+	}
+
+	url, ok := urls[endpoint]
+	if !ok {
+		return "", fmt.Errorf("%s: %w", endpoint, ErrNotDeployed)
+	}
+	return url, nil
+}
+
+// endpointURLs returns the global and EU endpoint URLs of the client.
+func (cl *Client) endpointURLs() (urls, euURLs map[Endpoint]string) {
+	return cl.urls, cl.euURLs
+}
+
+// WithEndpointURL sends calls to the endpoint to url instead, e.g. an enterprise gateway, a regional
+// mirror or a mock server. The override applies to the configured Deployment and, as the target is
+// trusted to route the data, with WithEUCompliance too.
+func WithEndpointURL(endpoint Endpoint, url string) Option {
+	return func(cfg *config) {
+		if cfg.EndpointURLs == nil {
+			cfg.EndpointURLs = make(map[Endpoint]string)
+		}
+		cfg.EndpointURLs[endpoint] = url
+	}
+}
+
+// WithBaseURLs overrides the URLs of several endpoints at once, see WithEndpointURL.
+//
+//	jina.WithBaseURLs(map[jina.Endpoint]string{
+//		jina.EndpointEmbeddings: "https://gateway.example.com/jina/v1/embeddings",
+//		jina.EndpointReader:     "https://gateway.example.com/jina/reader/",
+//	})
+func WithBaseURLs(urls map[Endpoint]string) Option {
+	return func(cfg *config) {
+		for endpoint, url := range urls {
+			WithEndpointURL(endpoint, url)(cfg)
+		}
+	}
+}
+
+// endpointURLs resolves the global and EU endpoint URLs of the config's deployment with its overrides.
+func (cfg *config) endpointURLs() (urls, euURLs map[Endpoint]string) {
+	urls, euURLs = defaultEndpointURLs, euEndpointURLs
+	if d := cfg.Deployment; d != nil {
+		urls, euURLs = d.URLs, d.EUURLs
+	}
+	if len(cfg.EndpointURLs) == 0 {
+		return urls, euURLs
+	}
+
+	urls, euURLs = maps.Clone(urls), maps.Clone(euURLs)
+	if urls == nil {
+		urls = make(map[Endpoint]string)
+	}
+	if euURLs == nil {
+		euURLs = make(map[Endpoint]string)
+	}
+	maps.Copy(urls, cfg.EndpointURLs)
+	maps.Copy(euURLs, cfg.EndpointURLs)
+
+	return urls, euURLs
+}
@@ -0,0 +1,102 @@
+package jina
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables read by NewClientFromEnv.
+const (
+	EnvAPIKey                = "JINA_API_KEY"
+	EnvEUCompliance          = "JINA_EU_COMPLIANCE"
+	EnvDialTimeout           = "JINA_DIAL_TIMEOUT"
+	EnvResponseHeaderTimeout = "JINA_RESPONSE_HEADER_TIMEOUT"
+)
+
+// NewClientFromEnv returns a client configured from the environment, so services can be reconfigured
+// without code changes:
+//
+//	JINA_API_KEY                   the API key, required unless options set WithAPIKey, WithAPIKeys or WithAPIKeyFunc
+//	JINA_EU_COMPLIANCE             "true" enables WithEUCompliance
+//	JINA_<ENDPOINT>_URL            overrides an endpoint URL, e.g. JINA_EMBEDDINGS_URL, or JINA_CUSTOM_URL for Client.Call, see WithEndpointURL
+//	JINA_<ENDPOINT>_TIMEOUT        total timeout of an endpoint, e.g. JINA_DEEPSEARCH_TIMEOUT=10m, see WithEndpointTimeout
+//	JINA_DIAL_TIMEOUT              see WithDialTimeout
+//	JINA_RESPONSE_HEADER_TIMEOUT   see WithResponseHeaderTimeout
+//
+// Timeouts are parsed with time.ParseDuration. options are applied after the environment and take
+// precedence over it.
+func NewClientFromEnv(options ...Option) (*Client, error) {
+	var envOptions []Option
+	if apiKey := os.Getenv(EnvAPIKey); apiKey != "" {
+		envOptions = append(envOptions, WithAPIKey(apiKey))
+	} else if !hasAPIKey(options) {
+		return nil, errors.New(EnvAPIKey + " is not set")
+	}
+
+	if value := os.Getenv(EnvEUCompliance); value != "" {
+		eu, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", EnvEUCompliance, err)
+		}
+		if eu {
+			envOptions = append(envOptions, WithEUCompliance())
+		}
+	}
+
+	for name, option := range map[string]func(time.Duration) Option{
+		EnvDialTimeout:           WithDialTimeout,
+		EnvResponseHeaderTimeout: WithResponseHeaderTimeout,
+	} {
+		d, ok, err := envDuration(name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			envOptions = append(envOptions, option(d))
+		}
+	}
+
+	for _, endpoint := range slices.Concat(Endpoints, []Endpoint{EndpointCustom}) {
+		prefix := "JINA_" + strings.ToUpper(string(endpoint))
+		if url := os.Getenv(prefix + "_URL"); url != "" {
+			envOptions = append(envOptions, WithEndpointURL(endpoint, url))
+		}
+		d, ok, err := envDuration(prefix + "_TIMEOUT")
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			envOptions = append(envOptions, WithEndpointTimeout(endpoint, d))
+		}
+	}
+
+	return NewClient(append(envOptions, options...)...), nil
+}
+
+// hasAPIKey reports whether options configure a source of API keys.
+func hasAPIKey(options []Option) bool {
+	cfg := defaultConfig()
+	for _, option := range options {
+		option(cfg)
+	}
+
+	return cfg.APIKey != "" || len(cfg.APIKeys) > 0 || cfg.APIKeyFunc != nil
+}
+
+func envDuration(name string) (time.Duration, bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, false, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return d, true, nil
+}
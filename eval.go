@@ -0,0 +1,284 @@
+package jina
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+)
+
+// EvalQuery is a query with graded relevance labels for its candidate documents.
+type EvalQuery struct {
+	Query     string
+	Documents []string
+
+	// Relevance grades the documents by index, e.g. 0 (irrelevant) to 3 (perfect).
+	// Unlisted documents are irrelevant.
+	Relevance map[int]float64
+}
+
+// EvalConfig configures EvaluateReranker.
+type EvalConfig struct {
+	// Reranker is the model evaluated. Defaults to the client's WithDefaultReranker.
+	Reranker RerankerModel
+
+	// Ks are the cutoffs NDCG and recall are reported at. Defaults to 1, 5 and 10.
+	Ks []int
+
+	// RetrievalModel, if set, first retrieves the RetrievalTopN documents most similar to the query
+	// with this embedding model and reranks only those, as a two-stage retrieval pipeline would.
+	// The retrieval ranking is reported as well, as a baseline.
+	RetrievalModel EmbeddingModel
+	RetrievalTopN  int // Defaults to 100
+
+	// Concurrency is the number of queries evaluated at once. Defaults to 4.
+	Concurrency int
+}
+
+// EvalMetrics are ranking metrics averaged over the queries with at least one relevant document.
+type EvalMetrics struct {
+	Queries int
+	MRR     float64
+	NDCG    map[int]float64 // By cutoff
+	Recall  map[int]float64 // By cutoff
+}
+
+// EvalReport is the result of EvaluateReranker.
+type EvalReport struct {
+	Reranker  RerankerModel
+	Reranking EvalMetrics
+	Retrieval *EvalMetrics // Set when a RetrievalModel was configured
+	Skipped   int          // Queries without relevant documents
+	Usage     Usage
+}
+
+// EvaluateReranker ranks the documents of every query with the reranker, optionally after embedding
+// retrieval, and reports NDCG, MRR and recall@k against the relevance labels, so rerankers can be
+// compared on your own data before switching models.
+func (cl *Client) EvaluateReranker(ctx context.Context, queries []EvalQuery, cfg EvalConfig, opts ...CallOption) (*EvalReport, error) {
+	if cfg.Reranker == "" {
+		cfg.Reranker = cl.cfg.DefaultRerankerModel
+	}
+	if len(cfg.Ks) == 0 {
+		cfg.Ks = []int{1, 5, 10}
+	}
+	if cfg.RetrievalTopN <= 0 {
+		cfg.RetrievalTopN = 100
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+
+	type rankings struct {
+		reranked, retrieved []int
+		usage               Usage
+	}
+	results, err := Batch(ctx, queries, func(ctx context.Context, query EvalQuery) (rankings, error) {
+		var r rankings
+		candidates := make([]int, len(query.Documents))
+		for i := range candidates {
+			candidates[i] = i
+		}
+		if cfg.RetrievalModel != "" {
+			retrieved, usage, err := cl.retrieve(ctx, query, cfg.RetrievalModel, opts)
+			if err != nil {
+				return r, err
+			}
+			r.retrieved = retrieved
+			r.usage = addUsage(r.usage, usage)
+			candidates = retrieved[:min(len(retrieved), cfg.RetrievalTopN)]
+		}
+
+		documents := make([]string, len(candidates))
+		for i, index := range candidates {
+			documents[i] = query.Documents[index]
+		}
+		returnDocuments := false
+		resp, err := cl.Rerank(ctx, RerankRequest{Model: cfg.Reranker, Query: query.Query, Documents: documents, ReturnDocuments: &returnDocuments}, opts...)
+		if err != nil {
+			return r, err
+		}
+		r.usage = addUsage(r.usage, resp.Usage)
+		slices.SortStableFunc(resp.Results, func(a, b RerankResult) int {
+			return cmp.Compare(b.RelevanceScore, a.RelevanceScore)
+		})
+		for _, result := range resp.Results {
+			if result.Index >= 0 && result.Index < len(candidates) {
+				r.reranked = append(r.reranked, candidates[result.Index])
+			}
+		}
+		return r, nil
+	}, WithBatchConcurrency(cfg.Concurrency))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &EvalReport{Reranker: cfg.Reranker}
+	reranking := newMetricsAccumulator(cfg.Ks)
+	var retrieval *metricsAccumulator
+	if cfg.RetrievalModel != "" {
+		retrieval = newMetricsAccumulator(cfg.Ks)
+	}
+	for i, result := range results {
+		report.Usage = addUsage(report.Usage, result.Response.usage)
+		relevance := queries[i].Relevance
+		if !slices.ContainsFunc(slices.Collect(maps.Values(relevance)), func(rel float64) bool { return rel > 0 }) {
+			report.Skipped++
+			continue
+		}
+		reranking.add(result.Response.reranked, relevance)
+		if retrieval != nil {
+			retrieval.add(result.Response.retrieved, relevance)
+		}
+	}
+	report.Reranking = reranking.metrics()
+	if retrieval != nil {
+		metrics := retrieval.metrics()
+		report.Retrieval = &metrics
+	}
+
+	return report, nil
+}
+
+// retrieve ranks the documents of query by cosine similarity of their embeddings.
+func (cl *Client) retrieve(ctx context.Context, query EvalQuery, model EmbeddingModel, opts []CallOption) ([]int, Usage, error) {
+	queryResp, err := cl.Embeddings(ctx, EmbeddingsRequest{
+		Model: model,
+		Task:  EmbeddingTaskRetrievalQuery,
+		Input: []EmbeddingInput{NewEmbeddingInputText(query.Query)},
+	}, opts...)
+	if err != nil {
+		return nil, Usage{}, fmt.Errorf("embed query: %w", err)
+	}
+	docsReq := EmbeddingsRequest{Model: model, Task: EmbeddingTaskRetrievalPassage}
+	for _, document := range query.Documents {
+		docsReq.Input = append(docsReq.Input, NewEmbeddingInputText(document))
+	}
+	docsResp, err := cl.Embeddings(ctx, docsReq, opts...)
+	if err != nil {
+		return nil, Usage{}, fmt.Errorf("embed documents: %w", err)
+	}
+	if len(queryResp.Data) == 0 {
+		return nil, Usage{}, fmt.Errorf("embed query: no embedding returned")
+	}
+
+	vectors := make([][]float32, len(query.Documents))
+	for _, data := range docsResp.Data {
+		if data.Index >= 0 && data.Index < len(vectors) {
+			vectors[data.Index] = data.Embedding
+		}
+	}
+	ranking := make([]int, 0, len(vectors))
+	scores := make([]float32, len(vectors))
+	for i, vec := range vectors {
+		if vec != nil {
+			scores[i] = CosineSimilarity(queryResp.Data[0].Embedding, vec)
+			ranking = append(ranking, i)
+		}
+	}
+	slices.SortStableFunc(ranking, func(a, b int) int {
+		return cmp.Compare(scores[b], scores[a])
+	})
+
+	return ranking, addUsage(queryResp.Usage, docsResp.Usage), nil
+}
+
+// NDCG returns the normalized discounted cumulative gain of ranking (document indices, best first)
+// at cutoff k, with gains 2^rel-1. The ideal ranking orders every labeled document by relevance.
+func NDCG(ranking []int, relevance map[int]float64, k int) float64 {
+	var dcg float64
+	for i, index := range ranking[:min(k, len(ranking))] {
+		dcg += (math.Exp2(relevance[index]) - 1) / math.Log2(float64(i+2))
+	}
+
+	ideal := slices.Collect(maps.Values(relevance))
+	slices.SortFunc(ideal, func(a, b float64) int { return cmp.Compare(b, a) })
+	var idcg float64
+	for i, rel := range ideal[:min(k, len(ideal))] {
+		idcg += (math.Exp2(rel) - 1) / math.Log2(float64(i+2))
+	}
+	if idcg == 0 {
+		return 0
+	}
+
+	return dcg / idcg
+}
+
+// ReciprocalRank returns 1/rank of the first relevant document of ranking, 0 if none is ranked.
+func ReciprocalRank(ranking []int, relevance map[int]float64) float64 {
+	for i, index := range ranking {
+		if relevance[index] > 0 {
+			return 1 / float64(i+1)
+		}
+	}
+
+	return 0
+}
+
+// RecallAtK returns the fraction of the relevant documents ranked within the first k.
+func RecallAtK(ranking []int, relevance map[int]float64, k int) float64 {
+	relevant := 0
+	for _, rel := range relevance {
+		if rel > 0 {
+			relevant++
+		}
+	}
+	if relevant == 0 {
+		return 0
+	}
+
+	found := 0
+	for _, index := range ranking[:min(k, len(ranking))] {
+		if relevance[index] > 0 {
+			found++
+		}
+	}
+
+	return float64(found) / float64(relevant)
+}
+
+type metricsAccumulator struct {
+	ks      []int
+	queries int
+	mrr     float64
+	ndcg    map[int]float64
+	recall  map[int]float64
+}
+
+func newMetricsAccumulator(ks []int) *metricsAccumulator {
+	return &metricsAccumulator{ks: ks, ndcg: map[int]float64{}, recall: map[int]float64{}}
+}
+
+func (m *metricsAccumulator) add(ranking []int, relevance map[int]float64) {
+	m.queries++
+	m.mrr += ReciprocalRank(ranking, relevance)
+	for _, k := range m.ks {
+		m.ndcg[k] += NDCG(ranking, relevance, k)
+		m.recall[k] += RecallAtK(ranking, relevance, k)
+	}
+}
+
+func (m *metricsAccumulator) metrics() EvalMetrics {
+	metrics := EvalMetrics{Queries: m.queries, NDCG: map[int]float64{}, Recall: map[int]float64{}}
+	if m.queries == 0 {
+		return metrics
+	}
+	n := float64(m.queries)
+	metrics.MRR = m.mrr / n
+	for _, k := range m.ks {
+		metrics.NDCG[k] = m.ndcg[k] / n
+		metrics.Recall[k] = m.recall[k] / n
+	}
+
+	return metrics
+}
+
+func addUsage(a, b Usage) Usage {
+	return Usage{
+		TotalTokens:      a.TotalTokens + b.TotalTokens,
+		PromptTokens:     a.PromptTokens + b.PromptTokens,
+		CompletionTokens: a.CompletionTokens + b.CompletionTokens,
+	}
+}
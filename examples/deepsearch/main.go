@@ -4,20 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
 
 	"github.com/fritzkeyzer/gojina"
 )
 
 func main() {
-	// Get your Jina AI API key for free: https://jina.ai/?sui=apikey
-	apiKey := os.Getenv("JINA_API_KEY")
-	if apiKey == "" {
-		log.Fatal("JINA_API_KEY environment variable is not set")
+	// Get your Jina AI API key for free: https://jina.ai/?sui=apikey and set JINA_API_KEY
+	client, err := jina.NewClientFromEnv()
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	client := jina.NewClient(jina.WithAPIKey(apiKey))
-
 	req := jina.DeepSearchRequest{
 		Model: "jina-deepsearch-v1",
 		Messages: []jina.VLMMessage{
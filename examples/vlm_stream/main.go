@@ -4,20 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
 
 	"github.com/fritzkeyzer/gojina"
 )
 
 func main() {
-	// Get your Jina AI API key for free: https://jina.ai/?sui=apikey
-	apiKey := os.Getenv("JINA_API_KEY")
-	if apiKey == "" {
-		log.Fatal("JINA_API_KEY environment variable is not set")
+	// Get your Jina AI API key for free: https://jina.ai/?sui=apikey and set JINA_API_KEY
+	client, err := jina.NewClientFromEnv()
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	client := jina.NewClient(jina.WithAPIKey(apiKey))
-
 	req := jina.VLMRequest{
 		Model: "jina-vlm",
 		Messages: []jina.VLMMessage{
@@ -36,7 +33,7 @@ func main() {
 	}
 
 	fmt.Println("Streaming response...")
-	err := client.VLMStream(context.Background(), req, func(resp *jina.VLMResponse) error {
+	err = client.VLMStream(context.Background(), req, func(resp *jina.VLMResponse) error {
 		fmt.Print(resp.Choices[0].Delta.Content)
 		return nil
 	})
@@ -0,0 +1,58 @@
+package jina
+
+import (
+	"errors"
+	"net/http"
+)
+
+// WithModelFallback retries calls to the endpoint that request primary with each fallback model
+// in turn while the model is unavailable or over quota (402, 429 and 5xx responses), keeping
+// pipelines alive during incidents. The model that served the call is reported in the Model field
+// of the response. Calls requesting another model, and streaming calls, are not affected.
+//
+//	jina.WithModelFallback(jina.EndpointEmbeddings, jina.EmbeddingModelV4, jina.EmbeddingModelV3)
+func WithModelFallback[M ~string](endpoint Endpoint, primary M, fallbacks ...M) Option {
+	return func(cfg *config) {
+		if cfg.ModelFallbacks == nil {
+			cfg.ModelFallbacks = make(map[Endpoint]modelFallback)
+		}
+		chain := modelFallback{primary: string(primary)}
+		for _, model := range fallbacks {
+			chain.fallbacks = append(chain.fallbacks, string(model))
+		}
+		cfg.ModelFallbacks[endpoint] = chain
+	}
+}
+
+type modelFallback struct {
+	primary   string
+	fallbacks []string
+}
+
+// withModelFallback calls fn with the requested model, then with the configured fallbacks
+// for as long as the previous model is unavailable.
+func withModelFallback[T any](cl *Client, endpoint Endpoint, model string, fn func(model string) (T, error)) (T, error) {
+	models := []string{model}
+	if chain, ok := cl.cfg.ModelFallbacks[endpoint]; ok && chain.primary == model {
+		models = append(models, chain.fallbacks...)
+	}
+
+	for i, model := range models {
+		resp, err := fn(model)
+		if err == nil || i == len(models)-1 || !modelUnavailable(err) {
+			return resp, err
+		}
+	}
+	panic("unreachable")
+}
+
+func modelUnavailable(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.StatusCode == http.StatusPaymentRequired ||
+		apiErr.StatusCode == http.StatusTooManyRequests ||
+		apiErr.StatusCode >= 500
+}
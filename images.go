@@ -0,0 +1,85 @@
+package jina
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Image is an image gathered by the Reader, see ReaderRequest.GatherImages.
+type Image struct {
+	URL string
+	Alt string // Alt text without the "Image N: " prefix added by the Reader
+
+	// Generated reports that the alt text may have been generated because ImageCaption was requested.
+	// The API does not mark which captions were present in the page, so every captioned image of such
+	// a response is reported as generated.
+	Generated bool
+}
+
+// markdownImagePattern matches ![alt](url) and ![alt](url "title").
+var markdownImagePattern = regexp.MustCompile(`!\[([^\]]*)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+
+// Images returns the images of the page in document order, parsed from the images summary of
+// structured responses or from the markdown of text responses. Requires GatherImages for the
+// summary, and ImageCaption to get alt text for images lacking one.
+func (r *ReaderResponse) Images() []Image {
+	var images []Image
+	if r.Structured != nil && len(r.Structured.Data.Images) > 0 {
+		type numbered struct {
+			n     int
+			image Image
+		}
+		var list []numbered
+		for key, url := range r.Structured.Data.Images {
+			n, alt := parseImageKey(key)
+			list = append(list, numbered{n: n, image: Image{URL: url, Alt: alt}})
+		}
+		slices.SortFunc(list, func(a, b numbered) int {
+			if a.n != b.n {
+				return a.n - b.n
+			}
+			return strings.Compare(a.image.URL, b.image.URL)
+		})
+		for _, item := range list {
+			images = append(images, item.image)
+		}
+	} else {
+		text := r.Text
+		if r.Structured != nil {
+			text = r.Structured.Data.Content
+		}
+		seen := map[string]bool{}
+		for _, match := range markdownImagePattern.FindAllStringSubmatch(text, -1) {
+			if seen[match[2]] {
+				continue
+			}
+			seen[match[2]] = true
+			_, alt := parseImageKey(match[1])
+			images = append(images, Image{URL: match[2], Alt: alt})
+		}
+	}
+
+	for i := range images {
+		images[i].Generated = r.imageCaption && images[i].Alt != ""
+	}
+
+	return images
+}
+
+// parseImageKey splits the "Image N: alt" labels used by the Reader into N and alt.
+// Labels in another format are returned as alt text, with N set to zero.
+func parseImageKey(key string) (int, string) {
+	rest, ok := strings.CutPrefix(key, "Image ")
+	if !ok {
+		return 0, key
+	}
+	num, alt, _ := strings.Cut(rest, ":")
+	n, err := strconv.Atoi(strings.TrimSpace(num))
+	if err != nil {
+		return 0, key
+	}
+
+	return n, strings.TrimSpace(alt)
+}
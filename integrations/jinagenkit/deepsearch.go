@@ -0,0 +1,104 @@
+package jinagenkit
+
+import (
+	"context"
+	"strings"
+
+	"github.com/firebase/genkit/go/ai"
+	"github.com/fritzkeyzer/gojina"
+)
+
+// DeepSearchConfig holds the per-request options of the DeepSearch model.
+type DeepSearchConfig struct {
+	ReasoningEffort string   `json:"reasoningEffort,omitempty"`
+	BudgetTokens    int      `json:"budgetTokens,omitempty"`
+	MaxAttempts     int      `json:"maxAttempts,omitempty"`
+	NoDirectAnswer  bool     `json:"noDirectAnswer,omitempty"`
+	MaxReturnedURLs int      `json:"maxReturnedURLs,omitempty"`
+	BoostHostnames  []string `json:"boostHostnames,omitempty"`
+}
+
+func newDeepSearch(client *jina.Client) *ai.ModelAction {
+	opts := &ai.ModelOptions{
+		Label: "Jina - DeepSearch",
+		Supports: &ai.ModelSupports{
+			Multiturn:  true,
+			SystemRole: true,
+		},
+	}
+
+	return ai.NewModelAction(DeepSearchName, opts, func(ctx context.Context, req *ai.ModelRequest, cfg DeepSearchConfig, cb ai.ModelStreamCallback) (*ai.ModelResponse, error) {
+		dsReq := jina.DeepSearchRequest{
+			Messages:        make([]jina.VLMMessage, 0, len(req.Messages)),
+			ReasoningEffort: cfg.ReasoningEffort,
+			BudgetTokens:    cfg.BudgetTokens,
+			MaxAttempts:     cfg.MaxAttempts,
+			NoDirectAnswer:  cfg.NoDirectAnswer,
+			MaxReturnedURLs: cfg.MaxReturnedURLs,
+			BoostHostnames:  cfg.BoostHostnames,
+		}
+		for _, msg := range req.Messages {
+			dsReq.Messages = append(dsReq.Messages, jina.NewVLMMessage(role(msg.Role), msg.Text()))
+		}
+
+		var text strings.Builder
+		var usage jina.Usage
+		if cb != nil {
+			err := client.DeepSearchStream(ctx, dsReq, func(chunk *jina.DeepSearchResponse) error {
+				if chunk.Usage.TotalTokens > 0 {
+					usage = chunk.Usage
+				}
+				if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+					return nil
+				}
+				// Reasoning steps are streamed as "think" deltas, only the answer is returned
+				delta := chunk.Choices[0].Delta
+				if delta.Type == "think" {
+					return nil
+				}
+				text.WriteString(delta.Content)
+				return cb(ctx, &ai.ModelResponseChunk{
+					Role:    ai.RoleModel,
+					Content: []*ai.Part{ai.NewTextPart(delta.Content)},
+				})
+			})
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			resp, err := client.DeepSearch(ctx, dsReq)
+			if err != nil {
+				return nil, err
+			}
+			if len(resp.Choices) > 0 {
+				text.WriteString(resp.Choices[0].Message.Content.Text)
+			}
+			usage = resp.Usage
+		}
+
+		return &ai.ModelResponse{
+			Request:      req,
+			FinishReason: ai.FinishReasonStop,
+			Message: &ai.Message{
+				Role:    ai.RoleModel,
+				Content: []*ai.Part{ai.NewTextPart(text.String())},
+			},
+			Usage: &ai.GenerationUsage{
+				InputTokens:  usage.PromptTokens,
+				OutputTokens: usage.CompletionTokens,
+				TotalTokens:  usage.TotalTokens,
+			},
+		}, nil
+	})
+}
+
+func role(r ai.Role) string {
+	switch r {
+	case ai.RoleModel:
+		return "assistant"
+	case ai.RoleSystem:
+		return "system"
+	default:
+		return "user"
+	}
+}
@@ -0,0 +1,92 @@
+package jinagenkit
+
+import (
+	"context"
+	"strings"
+
+	"github.com/firebase/genkit/go/ai"
+	"github.com/fritzkeyzer/gojina"
+)
+
+// EmbedConfig holds the per-request options of Jina embedders.
+type EmbedConfig struct {
+	Task         jina.EmbeddingTask `json:"task,omitempty"`
+	Dimensions   int                `json:"dimensions,omitempty"`
+	LateChunking bool               `json:"lateChunking,omitempty"`
+	Truncate     bool               `json:"truncate,omitempty"`
+}
+
+var embeddingDimensions = map[jina.EmbeddingModel]int{
+	jina.EmbeddingModelV4:       2048,
+	jina.EmbeddingModelV3:       1024,
+	jina.EmbeddingModelClipV2:   1024,
+	jina.EmbeddingModelCode0_5B: 896,
+	jina.EmbeddingModelCode1_5B: 1536,
+}
+
+func isMultimodal(model jina.EmbeddingModel) bool {
+	return model == jina.EmbeddingModelV4 || model == jina.EmbeddingModelClipV2
+}
+
+func newEmbedder(client *jina.Client, model jina.EmbeddingModel) *ai.EmbedderAction {
+	input := []string{"text"}
+	if isMultimodal(model) {
+		input = append(input, "image")
+	}
+
+	opts := &ai.EmbedderOptions{
+		Label:      "Jina - " + string(model),
+		Dimensions: embeddingDimensions[model],
+		Supports: &ai.EmbedderSupports{
+			Input:        input,
+			Multilingual: true,
+		},
+	}
+
+	return ai.NewEmbedderAction(EmbedderName(model), opts, func(ctx context.Context, req *ai.EmbedRequest, cfg EmbedConfig) (*ai.EmbedResponse, error) {
+		inputs := make([]jina.EmbeddingInput, len(req.Input))
+		for i, doc := range req.Input {
+			inputs[i] = embeddingInput(doc)
+		}
+
+		resp, err := client.Embeddings(ctx, jina.EmbeddingsRequest{
+			Model:        model,
+			Input:        inputs,
+			Task:         cfg.Task,
+			Dimensions:   cfg.Dimensions,
+			LateChunking: cfg.LateChunking,
+			Truncate:     cfg.Truncate,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		embeddings := make([]*ai.Embedding, len(req.Input))
+		for _, data := range resp.Data {
+			if data.Index >= 0 && data.Index < len(embeddings) {
+				embeddings[data.Index] = &ai.Embedding{Embedding: data.Embedding}
+			}
+		}
+
+		return &ai.EmbedResponse{Embeddings: embeddings}, nil
+	})
+}
+
+// embeddingInput embeds a document as text, or as an image when it only holds a media part.
+func embeddingInput(doc *ai.Document) jina.EmbeddingInput {
+	var text []string
+	var image string
+	for _, part := range doc.Content {
+		switch {
+		case part.IsText():
+			text = append(text, part.Text)
+		case part.IsMedia() && image == "":
+			image = part.Text
+		}
+	}
+	if len(text) == 0 && image != "" {
+		return jina.NewEmbeddingInputImage(image)
+	}
+
+	return jina.NewEmbeddingInputText(strings.Join(text, "\n"))
+}
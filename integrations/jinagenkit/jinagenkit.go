@@ -0,0 +1,107 @@
+// Package jinagenkit is a Firebase Genkit for Go plugin exposing Jina embedders,
+// rerankers and DeepSearch as Genkit actions.
+//
+//	g := genkit.Init(ctx, genkit.WithPlugins(&jinagenkit.Jina{}))
+//	resp, err := genkit.Embed(ctx, g,
+//		ai.WithEmbedderName("jina/jina-embeddings-v3"),
+//		ai.WithTextDocs("hello world"))
+package jinagenkit
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/firebase/genkit/go/core/api"
+	"github.com/fritzkeyzer/gojina"
+)
+
+const provider = "jina"
+
+// Jina is the Genkit plugin. The zero value registers every known embedder and reranker
+// plus DeepSearch, authenticating with the JINA_API_KEY environment variable.
+type Jina struct {
+	// APIKey is used when Client is nil. Defaults to the JINA_API_KEY environment variable.
+	APIKey string
+
+	// Client is the Jina client used by all actions. Optional.
+	Client *jina.Client
+
+	// Embedders lists the embedding models to register. Defaults to all known models.
+	Embedders []jina.EmbeddingModel
+
+	// Rerankers lists the reranker models to register. Defaults to all known models.
+	Rerankers []jina.RerankerModel
+
+	// DisableDeepSearch skips registering the DeepSearch model.
+	DisableDeepSearch bool
+
+	mu      sync.Mutex
+	initted bool
+}
+
+// Name implements api.Plugin.
+func (j *Jina) Name() string {
+	return provider
+}
+
+// Init implements api.Plugin.
+func (j *Jina) Init(ctx context.Context) []api.Action {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.initted {
+		panic("jinagenkit.Init already called")
+	}
+	j.initted = true
+
+	if j.Client == nil {
+		apiKey := j.APIKey
+		if apiKey == "" {
+			apiKey = os.Getenv("JINA_API_KEY")
+		}
+		j.Client = jina.NewClient(jina.WithAPIKey(apiKey))
+	}
+	if j.Embedders == nil {
+		j.Embedders = []jina.EmbeddingModel{
+			jina.EmbeddingModelV4,
+			jina.EmbeddingModelV3,
+			jina.EmbeddingModelClipV2,
+			jina.EmbeddingModelCode0_5B,
+			jina.EmbeddingModelCode1_5B,
+		}
+	}
+	if j.Rerankers == nil {
+		j.Rerankers = []jina.RerankerModel{
+			jina.RerankerModelV3,
+			jina.RerankerModelM0,
+			jina.RerankerModelV2BaseMultilingual,
+			jina.RerankerModelColbertV2,
+		}
+	}
+
+	var actions []api.Action
+	for _, model := range j.Embedders {
+		actions = append(actions, newEmbedder(j.Client, model))
+	}
+	for _, model := range j.Rerankers {
+		actions = append(actions, newReranker(j.Client, model))
+	}
+	if !j.DisableDeepSearch {
+		actions = append(actions, newDeepSearch(j.Client))
+	}
+
+	return actions
+}
+
+// EmbedderName returns the Genkit action name of an embedding model, e.g. "jina/jina-embeddings-v3".
+func EmbedderName(model jina.EmbeddingModel) string {
+	return api.NewName(provider, string(model))
+}
+
+// RerankerName returns the Genkit action name of a reranker model, e.g. "jina/jina-reranker-v3".
+func RerankerName(model jina.RerankerModel) string {
+	return api.NewName(provider, string(model))
+}
+
+// DeepSearchName is the Genkit action name of the DeepSearch model.
+var DeepSearchName = api.NewName(provider, jina.DeepSearchModelDefault)
@@ -0,0 +1,95 @@
+package jinagenkit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/firebase/genkit/go/ai"
+	"github.com/firebase/genkit/go/core"
+	"github.com/firebase/genkit/go/core/api"
+	"github.com/fritzkeyzer/gojina"
+)
+
+// actionTypeReranker matches the reranker action type of the Genkit JS SDK,
+// which the Go SDK does not define yet.
+const actionTypeReranker api.ActionType = "reranker"
+
+// RerankConfig holds the per-request options of Jina rerankers.
+type RerankConfig struct {
+	TopN int `json:"topN,omitempty"`
+}
+
+func newReranker(client *jina.Client, model jina.RerankerModel) *core.Action[*ai.RerankerRequest, *ai.RerankerResponse, struct{}] {
+	opts := &core.ActionOptions{
+		Description: "Jina - " + string(model),
+		Metadata: map[string]any{
+			"reranker": map[string]any{"label": "Jina - " + string(model)},
+		},
+	}
+
+	return core.NewActionOf(actionTypeReranker, RerankerName(model), opts, func(ctx context.Context, req *ai.RerankerRequest) (*ai.RerankerResponse, error) {
+		var cfg RerankConfig
+		if req.Options != nil {
+			data, err := json.Marshal(req.Options)
+			if err != nil {
+				return nil, fmt.Errorf("marshal options: %w", err)
+			}
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				return nil, fmt.Errorf("invalid reranker options: %w", err)
+			}
+		}
+
+		documents := make([]jina.RerankInput, len(req.Documents))
+		for i, doc := range req.Documents {
+			documents[i] = rerankInput(doc)
+		}
+
+		returnDocuments := false
+		rerankReq := jina.RerankRequest{
+			Model:           model,
+			DocumentsInput:  documents,
+			TopN:            cfg.TopN,
+			ReturnDocuments: &returnDocuments,
+		}
+		if req.Query != nil {
+			query := rerankInput(req.Query)
+			rerankReq.QueryInput = &query
+		}
+
+		resp, err := client.Rerank(ctx, rerankReq)
+		if err != nil {
+			return nil, err
+		}
+
+		ranked := make([]*ai.RankedDocumentData, 0, len(resp.Results))
+		for _, result := range resp.Results {
+			if result.Index < 0 || result.Index >= len(req.Documents) {
+				continue
+			}
+			ranked = append(ranked, &ai.RankedDocumentData{
+				Content:  req.Documents[result.Index].Content,
+				Metadata: &ai.RankedDocumentMetadata{Score: result.RelevanceScore},
+			})
+		}
+
+		return &ai.RerankerResponse{Documents: ranked}, nil
+	})
+}
+
+func rerankInput(doc *ai.Document) jina.RerankInput {
+	var input jina.RerankInput
+	var text []string
+	for _, part := range doc.Content {
+		switch {
+		case part.IsText():
+			text = append(text, part.Text)
+		case part.IsMedia() && input.Image == "":
+			input.Image = part.Text
+		}
+	}
+	input.Text = strings.Join(text, "\n")
+
+	return input
+}
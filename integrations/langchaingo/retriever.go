@@ -0,0 +1,224 @@
+// Package langchaingo adapts the Jina client to github.com/tmc/langchaingo.
+package langchaingo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/fritzkeyzer/gojina"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+)
+
+// Retriever is a langchaingo retriever backed by live web retrieval through Jina:
+// it searches the web for the query, reads the top results with the Reader API,
+// chunks their content and returns the chunks as documents.
+//
+// Every document carries its source in Metadata: "source" (URL), "title", "description",
+// "search_rank" (1-based position in the search results) and "chunk_index".
+type Retriever struct {
+	client *jina.Client
+
+	numResults     int
+	maxChunkLength int
+	maxDocuments   int
+	splitter       textsplitter.TextSplitter
+	rerankModel    jina.RerankerModel
+	search         jina.SearchRequest
+}
+
+var _ schema.Retriever = (*Retriever)(nil)
+
+type Option func(*Retriever)
+
+// WithNumResults sets how many search results are read. Default: 3.
+func WithNumResults(n int) Option {
+	return func(r *Retriever) {
+		r.numResults = n
+	}
+}
+
+// WithMaxChunkLength sets the maximum characters per chunk when chunking with the Jina Segmenter. Default: 1000.
+func WithMaxChunkLength(n int) Option {
+	return func(r *Retriever) {
+		r.maxChunkLength = n
+	}
+}
+
+// WithMaxDocuments limits the number of returned documents. Default: all chunks.
+func WithMaxDocuments(n int) Option {
+	return func(r *Retriever) {
+		r.maxDocuments = n
+	}
+}
+
+// WithSplitter chunks page content with a langchaingo text splitter instead of the Jina Segmenter.
+func WithSplitter(splitter textsplitter.TextSplitter) Option {
+	return func(r *Retriever) {
+		r.splitter = splitter
+	}
+}
+
+// WithReranker reranks the chunks against the query with the given model and sets Document.Score
+// to the relevance score. Documents are returned most relevant first.
+func WithReranker(model jina.RerankerModel) Option {
+	return func(r *Retriever) {
+		r.rerankModel = model
+	}
+}
+
+// WithSearchRequest sets the template for the search request (country, language, site filter, ...).
+// Query, MaxResults and JSONResponse are set by the retriever.
+func WithSearchRequest(req jina.SearchRequest) Option {
+	return func(r *Retriever) {
+		r.search = req
+	}
+}
+
+func NewRetriever(client *jina.Client, options ...Option) *Retriever {
+	r := &Retriever{
+		client:         client,
+		numResults:     3,
+		maxChunkLength: 1000,
+	}
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
+}
+
+// GetRelevantDocuments implements schema.Retriever.
+func (r *Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	searchReq := r.search
+	searchReq.Query = query
+	searchReq.MaxResults = r.numResults
+	searchReq.JSONResponse = true
+
+	searchResp, err := r.client.Search(ctx, searchReq)
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+
+	results := searchResp.Structured.Data
+	if len(results) > r.numResults {
+		results = results[:r.numResults]
+	}
+
+	pages := make([][]schema.Document, len(results))
+	errs := make([]error, len(results))
+	var wg sync.WaitGroup
+	for i, result := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pages[i], errs[i] = r.readPage(ctx, result, i+1)
+		}()
+	}
+	wg.Wait()
+
+	var docs []schema.Document
+	for _, page := range pages {
+		docs = append(docs, page...)
+	}
+	if len(docs) == 0 && len(results) > 0 {
+		return nil, fmt.Errorf("read search results: %w", errors.Join(errs...))
+	}
+
+	if r.rerankModel != "" && len(docs) > 0 {
+		if docs, err = r.rerank(ctx, query, docs); err != nil {
+			return nil, err
+		}
+	}
+	if r.maxDocuments > 0 && len(docs) > r.maxDocuments {
+		docs = docs[:r.maxDocuments]
+	}
+
+	return docs, nil
+}
+
+func (r *Retriever) readPage(ctx context.Context, result jina.SearchResultData, rank int) ([]schema.Document, error) {
+	readResp, err := r.client.Reader(ctx, jina.ReaderRequest{
+		URL:          result.URL,
+		JSONResponse: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", result.URL, err)
+	}
+
+	page := readResp.Structured.Data
+	chunks, err := r.chunk(ctx, page.Content)
+	if err != nil {
+		return nil, fmt.Errorf("chunk %s: %w", result.URL, err)
+	}
+
+	title := page.Title
+	if title == "" {
+		title = result.Title
+	}
+
+	docs := make([]schema.Document, 0, len(chunks))
+	for i, chunk := range chunks {
+		docs = append(docs, schema.Document{
+			PageContent: chunk,
+			Metadata: map[string]any{
+				"source":      result.URL,
+				"title":       title,
+				"description": result.Description,
+				"search_rank": rank,
+				"chunk_index": i,
+			},
+		})
+	}
+
+	return docs, nil
+}
+
+func (r *Retriever) chunk(ctx context.Context, content string) ([]string, error) {
+	if content == "" {
+		return nil, nil
+	}
+	if r.splitter != nil {
+		return r.splitter.SplitText(content)
+	}
+
+	resp, err := r.client.Segment(ctx, jina.SegmenterRequest{
+		Content:        content,
+		ReturnChunks:   true,
+		MaxChunkLength: r.maxChunkLength,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Chunks, nil
+}
+
+func (r *Retriever) rerank(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error) {
+	contents := make([]string, len(docs))
+	for i, doc := range docs {
+		contents[i] = doc.PageContent
+	}
+
+	returnDocuments := false
+	resp, err := r.client.Rerank(ctx, jina.RerankRequest{
+		Model:           r.rerankModel,
+		Query:           query,
+		Documents:       contents,
+		ReturnDocuments: &returnDocuments,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rerank: %w", err)
+	}
+
+	ranked := make([]schema.Document, 0, len(resp.Results))
+	for _, result := range resp.Results {
+		doc := docs[result.Index]
+		doc.Score = float32(result.RelevanceScore)
+		ranked = append(ranked, doc)
+	}
+
+	return ranked, nil
+}
@@ -0,0 +1,42 @@
+package jina
+
+import "net/http"
+
+// RoundTripFunc sends a request and returns its response, like http.RoundTripper.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Interceptor wraps the sending of requests, to swap credentials, add telemetry, serve responses
+// from a cache or inject faults for every endpoint in one place:
+//
+//	jina.WithInterceptor(func(next jina.RoundTripFunc) jina.RoundTripFunc {
+//		return func(req *http.Request) (*http.Response, error) {
+//			start := time.Now()
+//			resp, err := next(req)
+//			log.Printf("%s %s took %v", req.Method, req.URL, time.Since(start))
+//			return resp, err
+//		}
+//	})
+//
+// Interceptors see every attempt of a call, authenticated and ready to send, and may return a
+// response without calling next. Error responses they return are handled like those of the API.
+type Interceptor func(next RoundTripFunc) RoundTripFunc
+
+// WithInterceptor adds an interceptor around the sending of requests. Interceptors run in the order
+// they were added, the first one sees the request first and the response last.
+func WithInterceptor(interceptor Interceptor) Option {
+	return func(cfg *config) {
+		cfg.Interceptors = append(cfg.Interceptors, interceptor)
+	}
+}
+
+// roundTrip returns the client's HTTP round trip wrapped in its interceptors.
+func (cl *Client) roundTrip() RoundTripFunc {
+	next := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return cl.httpClient.Do(req)
+	})
+	for i := len(cl.cfg.Interceptors) - 1; i >= 0; i-- {
+		next = cl.cfg.Interceptors[i](next)
+	}
+
+	return next
+}
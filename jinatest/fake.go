@@ -0,0 +1,456 @@
+package jinatest
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/fritzkeyzer/gojina"
+)
+
+// DefaultDimensions is the length of the fake embeddings of requests without Dimensions.
+const DefaultDimensions = 32
+
+// fake returns the handler answering the endpoint with fake data derived from the request.
+func (s *Server) fake(endpoint jina.Endpoint) http.HandlerFunc {
+	switch endpoint {
+	case jina.EndpointEmbeddings:
+		return fakeEmbeddings
+	case jina.EndpointRerank:
+		return fakeRerank
+	case jina.EndpointClassify:
+		return s.fakeClassify
+	case jina.EndpointTrain:
+		return s.fakeTrain
+	case jina.EndpointSegment:
+		return fakeSegment
+	case jina.EndpointReader:
+		return fakeReader
+	case jina.EndpointSearch:
+		return fakeSearch
+	default:
+		return fakeChat
+	}
+}
+
+// decode unmarshals the request body into v, answering 422 like the API if it is invalid.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]any{"detail": fmt.Sprintf("invalid request body: %v", err)})
+		return false
+	}
+
+	return true
+}
+
+// inputText returns the text of a string or {"text": ...} input, and the image of {"image": ...} ones.
+func inputText(input json.RawMessage) string {
+	var text string
+	if json.Unmarshal(input, &text) == nil {
+		return text
+	}
+	var object struct {
+		Text  string `json:"text"`
+		Image string `json:"image"`
+	}
+	json.Unmarshal(input, &object)
+
+	return cmp.Or(object.Text, object.Image)
+}
+
+// tokens approximates the token count of text by its words.
+func tokens(text string) int {
+	return max(len(strings.Fields(text)), 1)
+}
+
+// words returns the lower-cased words of text.
+func words(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		set[strings.Trim(word, ".,;:!?\"'()")] = true
+	}
+
+	return set
+}
+
+// overlap returns the fraction of the words of query found in text.
+func overlap(query, text string) float64 {
+	queryWords, textWords := words(query), words(text)
+	if len(queryWords) == 0 {
+		return 0
+	}
+	shared := 0
+	for word := range queryWords {
+		if textWords[word] {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(queryWords))
+}
+
+// embed returns a deterministic unit vector derived from text.
+func embed(text string, dimensions int) []float32 {
+	h := fnv.New64a()
+	h.Write([]byte(text))
+	seed := h.Sum64()
+
+	vector := make([]float32, dimensions)
+	var norm float64
+	for i := range vector {
+		seed = seed*6364136223846793005 + 1442695040888963407
+		vector[i] = float32(int64(seed>>11))/float32(1<<52) - 1
+		norm += float64(vector[i]) * float64(vector[i])
+	}
+	norm = math.Sqrt(norm)
+	for i := range vector {
+		vector[i] = float32(float64(vector[i]) / norm)
+	}
+
+	return vector
+}
+
+func fakeEmbeddings(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Model      string            `json:"model"`
+		Input      []json.RawMessage `json:"input"`
+		Dimensions int               `json:"dimensions"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	resp := jina.EmbeddingsResponse{Model: req.Model}
+	for i, input := range req.Input {
+		text := inputText(input)
+		resp.Data = append(resp.Data, jina.EmbeddingData{
+			Object:    "embedding",
+			Index:     i,
+			Embedding: embed(text, cmp.Or(req.Dimensions, DefaultDimensions)),
+		})
+		resp.Usage.TotalTokens += tokens(text)
+	}
+	resp.Usage.PromptTokens = resp.Usage.TotalTokens
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func fakeRerank(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Model           string            `json:"model"`
+		Query           json.RawMessage   `json:"query"`
+		Documents       []json.RawMessage `json:"documents"`
+		TopN            int               `json:"top_n"`
+		ReturnDocuments *bool             `json:"return_documents"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	query := inputText(req.Query)
+	resp := jina.RerankResponse{Model: req.Model}
+	resp.Usage.TotalTokens = tokens(query)
+	for i, document := range req.Documents {
+		text := inputText(document)
+		result := jina.RerankResult{Index: i, RelevanceScore: overlap(query, text)}
+		if req.ReturnDocuments == nil || *req.ReturnDocuments {
+			result.Document = document
+		}
+		resp.Results = append(resp.Results, result)
+		resp.Usage.TotalTokens += tokens(text)
+	}
+	slices.SortStableFunc(resp.Results, func(a, b jina.RerankResult) int {
+		return cmp.Compare(b.RelevanceScore, a.RelevanceScore)
+	})
+	if req.TopN > 0 && req.TopN < len(resp.Results) {
+		resp.Results = resp.Results[:req.TopN]
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) fakeTrain(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ClassifierID string `json:"classifier_id"`
+		Input        []struct {
+			Label string `json:"label"`
+		} `json:"input"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	id := cmp.Or(req.ClassifierID, fmt.Sprintf("jinatest-classifier-%d", len(s.classifiers)+1))
+	labels := s.classifiers[id]
+	for _, example := range req.Input {
+		if !slices.Contains(labels, example.Label) {
+			labels = append(labels, example.Label)
+		}
+	}
+	s.classifiers[id] = labels
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, jina.TrainClassifierResponse{
+		ClassifierID: id,
+		NumSamples:   len(req.Input),
+		Usage:        jina.Usage{TotalTokens: len(req.Input)},
+	})
+}
+
+func (s *Server) fakeClassify(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Model        string            `json:"model"`
+		ClassifierID string            `json:"classifier_id"`
+		Input        []json.RawMessage `json:"input"`
+		Labels       []string          `json:"labels"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	labels := req.Labels
+	if req.ClassifierID != "" {
+		s.mu.Lock()
+		trained, ok := s.classifiers[req.ClassifierID]
+		s.mu.Unlock()
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]any{"detail": fmt.Sprintf("classifier %s not found", req.ClassifierID)})
+			return
+		}
+		labels = trained
+	}
+	if len(labels) == 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]any{"detail": "labels are required"})
+		return
+	}
+
+	resp := jina.ClassificationResponse{Model: req.Model}
+	for i, input := range req.Input {
+		text := inputText(input)
+		data := jina.ClassificationData{Object: "classification", Index: i}
+		// Scores are the word overlap of each label, plus one so they stay positive, normalized to sum to 1
+		var total float64
+		for _, label := range labels {
+			score := overlap(label, text) + 1
+			data.Predictions = append(data.Predictions, jina.ClassificationLabel{Label: label, Score: score})
+			total += score
+		}
+		for j := range data.Predictions {
+			data.Predictions[j].Score /= total
+			if data.Predictions[j].Score > data.Score {
+				data.Prediction, data.Score = data.Predictions[j].Label, data.Predictions[j].Score
+			}
+		}
+		resp.Data = append(resp.Data, data)
+		resp.Usage.TotalTokens += tokens(text)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// fakeSegment splits the content into tokens at whitespace and into chunks at blank lines.
+func fakeSegment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Content      string `json:"content"`
+		Tokenizer    string `json:"tokenizer"`
+		ReturnTokens bool   `json:"return_tokens"`
+		ReturnChunks bool   `json:"return_chunks"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	resp := map[string]any{
+		"num_tokens": len(strings.Fields(req.Content)),
+		"tokenizer":  cmp.Or(req.Tokenizer, "cl100k_base"),
+		"usage":      jina.Usage{TotalTokens: len(strings.Fields(req.Content))},
+	}
+
+	chunks, positions := []string{req.Content}, [][]int{{0, len(req.Content)}}
+	if req.ReturnChunks {
+		chunks, positions = nil, nil
+		start := 0
+		for _, paragraph := range strings.SplitAfter(req.Content, "\n\n") {
+			if paragraph != "" {
+				chunks = append(chunks, paragraph)
+				positions = append(positions, []int{start, start + len(paragraph)})
+			}
+			start += len(paragraph)
+		}
+		resp["num_chunks"] = len(chunks)
+		resp["chunks"] = chunks
+		resp["chunk_positions"] = positions
+	}
+	if req.ReturnTokens {
+		chunkTokens := make([][][]any, len(chunks))
+		id := 0
+		for i, chunk := range chunks {
+			chunkTokens[i] = [][]any{}
+			for _, word := range strings.Fields(chunk) {
+				chunkTokens[i] = append(chunkTokens[i], []any{word, []int{id}})
+				id++
+			}
+		}
+		resp["tokens"] = chunkTokens
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// wantsJSON reports whether the Reader or Search request asked for a JSON response.
+func wantsJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+func fakeReader(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		URL string `json:"url"`
+		PDF string `json:"pdf"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	url := cmp.Or(req.URL, "pdf")
+	title := "jinatest page"
+	content := fmt.Sprintf("# %s\n\nThe content of %s.", title, url)
+
+	if !wantsJSON(r) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintf(w, "Title: %s\n\nURL Source: %s\n\nMarkdown Content:\n%s", title, url, content)
+		return
+	}
+	usage := map[string]int{"tokens": tokens(content)}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"code":   200,
+		"status": 20000,
+		"data": map[string]any{
+			"title":   title,
+			"url":     url,
+			"content": content,
+			"usage":   usage,
+		},
+		"meta": map[string]any{"usage": usage},
+	})
+}
+
+// searchResults is the number of results of a fake search without MaxResults.
+const searchResults = 3
+
+func fakeSearch(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query      string `json:"q"`
+		MaxResults int    `json:"num"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	var results []jina.SearchResultData
+	for i := range cmp.Or(req.MaxResults, searchResults) {
+		result := jina.SearchResultData{
+			Title:       fmt.Sprintf("Result %d for %s", i+1, req.Query),
+			URL:         fmt.Sprintf("https://example.com/%d", i+1),
+			Description: fmt.Sprintf("A page about %s.", req.Query),
+		}
+		if r.Header.Get("X-Respond-With") != "no-content" {
+			result.Content = fmt.Sprintf("# %s\n\nThe content of a page about %s.", result.Title, req.Query)
+		}
+		result.Usage.Tokens = tokens(result.Description + " " + result.Content)
+		results = append(results, result)
+	}
+
+	if !wantsJSON(r) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		for i, result := range results {
+			fmt.Fprintf(w, "[%d] Title: %s\n[%d] URL Source: %s\n[%d] Description: %s\n", i+1, result.Title, i+1, result.URL, i+1, result.Description)
+			if result.Content != "" {
+				fmt.Fprintf(w, "[%d] Markdown Content:\n%s\n", i+1, result.Content)
+			}
+			fmt.Fprintln(w)
+		}
+		return
+	}
+	resp := jina.StructuredSearchResponse{Code: 200, Status: 20000, Data: results}
+	for _, result := range results {
+		resp.Usage.Tokens += result.Usage.Tokens
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// fakeChat answers VLM and DeepSearch requests by echoing the last message, streamed word by word
+// when the request asks for a stream.
+func fakeChat(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Model    string `json:"model"`
+		Stream   bool   `json:"stream"`
+		Messages []struct {
+			Content json.RawMessage `json:"content"`
+		} `json:"messages"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	var prompt string
+	if len(req.Messages) > 0 {
+		prompt = messageText(req.Messages[len(req.Messages)-1].Content)
+	}
+	answer := "You said: " + prompt
+	usage := jina.Usage{PromptTokens: tokens(prompt), CompletionTokens: tokens(answer)}
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+
+	completion := func(object string, choice map[string]any) map[string]any {
+		return map[string]any{
+			"id":      "jinatest-completion",
+			"object":  object,
+			"created": 0,
+			"model":   req.Model,
+			"choices": []any{choice},
+		}
+	}
+	if !req.Stream {
+		resp := completion("chat.completion", map[string]any{
+			"index":         0,
+			"message":       map[string]any{"role": "assistant", "content": answer},
+			"finish_reason": "stop",
+		})
+		resp["usage"] = usage
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	var events [][]byte
+	pieces := strings.SplitAfter(answer, " ")
+	for i, piece := range pieces {
+		delta := map[string]any{"content": piece}
+		if i == 0 {
+			delta["role"] = "assistant"
+		}
+		chunk := completion("chat.completion.chunk", map[string]any{"index": 0, "delta": delta})
+		if i == len(pieces)-1 {
+			chunk["choices"] = []any{map[string]any{"index": 0, "delta": delta, "finish_reason": "stop"}}
+			chunk["usage"] = usage
+		}
+		event, _ := json.Marshal(chunk)
+		events = append(events, event)
+	}
+	writeEvents(w, events)
+}
+
+// messageText returns the text of a string message content, or the text parts of a multimodal one.
+func messageText(content json.RawMessage) string {
+	var text string
+	if json.Unmarshal(content, &text) == nil {
+		return text
+	}
+	var parts []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	json.Unmarshal(content, &parts)
+	var texts []string
+	for _, part := range parts {
+		if part.Type == "text" {
+			texts = append(texts, part.Text)
+		}
+	}
+
+	return strings.Join(texts, " ")
+}
@@ -0,0 +1,324 @@
+// Package jinatest provides an in-process fake of the Jina APIs, so code built on the jina client can
+// be unit-tested without API keys or network access:
+//
+//	srv := jinatest.NewServer()
+//	defer srv.Close()
+//
+//	client := srv.Client()
+//	resp, err := client.Embeddings(ctx, req)
+//
+// Every endpoint answers with deterministic fake data derived from the request: embeddings are hashed
+// from their input, rerank and classify scores count shared words, and VLM and DeepSearch echo the
+// last message, streamed as server-sent events when the request asks for it. Respond and RespondStream
+// replace the fake with canned responses, Handle with a custom handler, and Fail injects errors.
+package jinatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fritzkeyzer/gojina"
+)
+
+// APIKey is the key of the clients returned by Server.Client.
+const APIKey = "jinatest-api-key"
+
+// paths holds the path each endpoint is served on.
+var paths = map[jina.Endpoint]string{
+	jina.EndpointEmbeddings: "/v1/embeddings",
+	jina.EndpointRerank:     "/v1/rerank",
+	jina.EndpointClassify:   "/v1/classify",
+	jina.EndpointTrain:      "/v1/train",
+	jina.EndpointSegment:    "/segment/",
+	jina.EndpointReader:     "/reader/",
+	jina.EndpointSearch:     "/search/",
+	jina.EndpointDeepSearch: "/deepsearch/v1/chat/completions",
+	jina.EndpointVLM:        "/vlm/v1/chat/completions",
+}
+
+// Server is a fake Jina API server. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	handlers    map[jina.Endpoint]http.HandlerFunc
+	failures    map[jina.Endpoint][]Failure
+	requests    []Request
+	classifiers map[string][]string // Labels of the trained classifiers by ID
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Endpoint jina.Endpoint
+	Method   string
+	Path     string
+	Header   http.Header
+	Body     []byte
+}
+
+// Decode unmarshals the JSON body of the request into v.
+func (r Request) Decode(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Failure describes an error response injected with Server.Fail.
+type Failure struct {
+	// Status is the HTTP status of the response. Defaults to 500.
+	Status int
+
+	// Detail is the error message of the response body.
+	Detail string
+
+	// RetryAfter, if set, is sent as the Retry-After header.
+	RetryAfter time.Duration
+
+	// Delay holds the response back, e.g. to exceed a timeout. A request canceled meanwhile gets none.
+	Delay time.Duration
+
+	// Disconnect closes the connection without a response, like a network failure.
+	Disconnect bool
+}
+
+// NewServer starts a fake Jina API server. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		handlers:    make(map[jina.Endpoint]http.HandlerFunc),
+		failures:    make(map[jina.Endpoint][]Failure),
+		classifiers: make(map[string][]string),
+	}
+	mux := http.NewServeMux()
+	for endpoint, path := range paths {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			s.serve(endpoint, w, r)
+		})
+	}
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// URL returns the URL the endpoint is served on.
+func (s *Server) URL(endpoint jina.Endpoint) string {
+	return s.Server.URL + paths[endpoint]
+}
+
+// URLs returns the URLs of every endpoint, to point a client at the server with jina.WithBaseURLs.
+func (s *Server) URLs() map[jina.Endpoint]string {
+	urls := make(map[jina.Endpoint]string, len(paths))
+	for endpoint := range paths {
+		urls[endpoint] = s.URL(endpoint)
+	}
+
+	return urls
+}
+
+// Client returns a client sending every call to the server, configured with opts.
+// EU compliance routes to the server too.
+func (s *Server) Client(opts ...jina.Option) *jina.Client {
+	return jina.NewClient(append([]jina.Option{
+		jina.WithAPIKey(APIKey),
+		jina.WithHTTPClient(s.Server.Client()),
+		jina.WithBaseURLs(s.URLs()),
+	}, opts...)...)
+}
+
+// Handle replaces the fake of the endpoint with handler. A nil handler restores the fake.
+func (s *Server) Handle(endpoint jina.Endpoint, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if handler == nil {
+		delete(s.handlers, endpoint)
+		return
+	}
+	s.handlers[endpoint] = handler
+}
+
+// Respond answers every request to the endpoint with status and body, marshalled to JSON unless it
+// is a string or []byte, which are sent verbatim.
+func (s *Server) Respond(endpoint jina.Endpoint, status int, body any) {
+	data, err := marshal(body)
+	s.Handle(endpoint, func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if json.Valid(data) {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.WriteHeader(status)
+		w.Write(data)
+	})
+}
+
+// RespondStream answers every request to the endpoint with chunks, each marshalled like Respond and
+// sent as a server-sent event, followed by the closing [DONE] event.
+func (s *Server) RespondStream(endpoint jina.Endpoint, chunks ...any) {
+	events := make([][]byte, len(chunks))
+	var err error
+	for i, chunk := range chunks {
+		if events[i], err = marshal(chunk); err != nil {
+			break
+		}
+	}
+	s.Handle(endpoint, func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeEvents(w, events)
+	})
+}
+
+// Fail answers the next n requests to the endpoint with failure before resuming normal responses.
+// Failures of successive calls queue up.
+func (s *Server) Fail(endpoint jina.Endpoint, n int, failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for range n {
+		s.failures[endpoint] = append(s.failures[endpoint], failure)
+	}
+}
+
+// Requests returns the requests received for the endpoint, in order.
+func (s *Server) Requests(endpoint jina.Endpoint) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var requests []Request
+	for _, req := range s.requests {
+		if req.Endpoint == endpoint {
+			requests = append(requests, req)
+		}
+	}
+
+	return requests
+}
+
+// Reset forgets the received requests, pending failures and trained classifiers,
+// and restores the fakes of every endpoint.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers = make(map[jina.Endpoint]http.HandlerFunc)
+	s.failures = make(map[jina.Endpoint][]Failure)
+	s.classifiers = make(map[string][]string)
+	s.requests = nil
+}
+
+func (s *Server) serve(endpoint jina.Endpoint, w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Endpoint: endpoint,
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		Header:   r.Header.Clone(),
+		Body:     body,
+	})
+	var failure *Failure
+	if pending := s.failures[endpoint]; len(pending) > 0 {
+		failure, s.failures[endpoint] = &pending[0], pending[1:]
+	}
+	handler, ok := s.handlers[endpoint]
+	s.mu.Unlock()
+
+	if failure != nil {
+		fail(endpoint, w, r, *failure)
+		return
+	}
+	if !ok {
+		handler = s.fake(endpoint)
+	}
+	handler(w, r)
+}
+
+// fail writes the failure in the error format of the endpoint.
+func fail(endpoint jina.Endpoint, w http.ResponseWriter, r *http.Request, failure Failure) {
+	if failure.Delay > 0 {
+		select {
+		case <-time.After(failure.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if failure.Disconnect {
+		if conn, _, err := http.NewResponseController(w).Hijack(); err == nil {
+			conn.Close()
+		}
+		return
+	}
+
+	status := failure.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	detail := failure.Detail
+	if detail == "" {
+		detail = http.StatusText(status)
+	}
+	if failure.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(failure.RetryAfter.Round(time.Second)/time.Second)))
+	}
+
+	// The model APIs report {"detail": ...}, the Reader and Search APIs {"name": ..., "message": ...}
+	var body any = map[string]any{"detail": detail}
+	if endpoint == jina.EndpointReader || endpoint == jina.EndpointSearch {
+		body = map[string]any{"code": status, "name": "InjectedFailure", "message": detail}
+	}
+	writeJSON(w, status, body)
+}
+
+func marshal(body any) ([]byte, error) {
+	switch body := body.(type) {
+	case []byte:
+		return body, nil
+	case string:
+		return []byte(body), nil
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("jinatest: marshal response: %w", err)
+		}
+		return data, nil
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// writeEvents streams events as server-sent events, flushing each, then the closing [DONE] event.
+func writeEvents(w http.ResponseWriter, events [][]byte) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	flusher, _ := w.(http.Flusher)
+	for _, event := range append(events, []byte("[DONE]")) {
+		fmt.Fprintf(w, "data: %s\n\n", event)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
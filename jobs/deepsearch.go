@@ -0,0 +1,332 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fritzkeyzer/gojina"
+)
+
+// StatusCanceled is the status of a DeepSearch job stopped with DeepSearch.Cancel.
+const StatusCanceled Status = "canceled"
+
+// DeepSearchOption configures DeepSearch.
+type DeepSearchOption func(*DeepSearch)
+
+// WithProgressInterval sets how often the partial answer of a running DeepSearch job is saved.
+// Defaults to 5 seconds.
+func WithProgressInterval(d time.Duration) DeepSearchOption {
+	return func(ds *DeepSearch) {
+		ds.progressInterval = d
+	}
+}
+
+// WithPollInterval sets how often Wait polls the store for jobs running in another process.
+// Defaults to 2 seconds.
+func WithPollInterval(d time.Duration) DeepSearchOption {
+	return func(ds *DeepSearch) {
+		ds.pollInterval = d
+	}
+}
+
+// WithDeepSearchClock replaces the wall clock used to time progress saves, polls and job timestamps.
+// Defaults to jina.SystemClock.
+func WithDeepSearchClock(clock jina.Clock) DeepSearchOption {
+	return func(ds *DeepSearch) {
+		ds.clock = clock
+	}
+}
+
+// DeepSearch runs DeepSearch requests as background jobs, so web backends can hand out a job ID
+// instead of holding a request open for the minutes an answer may take. Job states, including the
+// partial answer while the job runs, are persisted to a Store and can be observed from any process.
+type DeepSearch struct {
+	client *jina.Client
+	store  Store
+
+	progressInterval time.Duration
+	pollInterval     time.Duration
+	clock            jina.Clock
+
+	mu      sync.Mutex
+	running map[string]*deepSearchRun
+}
+
+type deepSearchRun struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// Set when done is closed: the final state, and the error saving it
+	state   *DeepSearchState
+	saveErr error
+}
+
+// NewDeepSearch returns a job runner searching with client and persisting job state to store.
+func NewDeepSearch(client *jina.Client, store Store, opts ...DeepSearchOption) *DeepSearch {
+	ds := &DeepSearch{
+		client:           client,
+		store:            store,
+		progressInterval: 5 * time.Second,
+		pollInterval:     2 * time.Second,
+		clock:            jina.SystemClock,
+		running:          make(map[string]*deepSearchRun),
+	}
+	for _, opt := range opts {
+		opt(ds)
+	}
+
+	return ds
+}
+
+// DeepSearchState is the persisted state of a DeepSearch job.
+type DeepSearchState struct {
+	ID      string                 `json:"id"`
+	Status  Status                 `json:"status"`
+	Request jina.DeepSearchRequest `json:"request"`
+
+	// Answer is the answer streamed so far, complete once the job completed.
+	Answer      string   `json:"answer"`
+	Queries     []string `json:"queries,omitempty"`
+	VisitedURLs []string `json:"visited_urls,omitempty"`
+	ReadURLs    []string `json:"read_urls,omitempty"`
+
+	Error string     `json:"error,omitempty"`
+	Usage jina.Usage `json:"usage"`
+
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Done reports whether the job stopped running.
+func (s *DeepSearchState) Done() bool {
+	return s.Status != StatusRunning
+}
+
+// Submit saves a new job for req and runs it in the background, returning its ID. The job keeps
+// running when ctx is cancelled, use Cancel to stop it.
+func (ds *DeepSearch) Submit(ctx context.Context, req jina.DeepSearchRequest) (string, error) {
+	id, err := newJobID()
+	if err != nil {
+		return "", err
+	}
+
+	now := ds.clock.Now()
+	state := &DeepSearchState{
+		ID:        id,
+		Status:    StatusRunning,
+		Request:   req,
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+	run, _ := ds.reserve(ctx, id)
+	if err := ds.save(ctx, state); err != nil {
+		ds.release(id, run)
+		return "", err
+	}
+	ds.start(run, state)
+
+	return id, nil
+}
+
+// Resume restarts job id in the background when it is not completed, e.g. after the process
+// running it exited. DeepSearch cannot continue a session, so the search starts over.
+func (ds *DeepSearch) Resume(ctx context.Context, id string) error {
+	run, ok := ds.reserve(ctx, id)
+	if !ok {
+		return nil
+	}
+
+	state, err := ds.load(ctx, id)
+	if err != nil {
+		ds.release(id, run)
+		return err
+	}
+	if state.Status == StatusCompleted {
+		ds.release(id, run)
+		return nil
+	}
+
+	*state = DeepSearchState{
+		ID:        state.ID,
+		Status:    StatusRunning,
+		Request:   state.Request,
+		StartedAt: state.StartedAt,
+	}
+	if err := ds.save(ctx, state); err != nil {
+		ds.release(id, run)
+		return err
+	}
+	ds.start(run, state)
+
+	return nil
+}
+
+// Status returns the state of job id as last saved, also while it runs in another process.
+func (ds *DeepSearch) Status(ctx context.Context, id string) (*DeepSearchState, error) {
+	return ds.load(ctx, id)
+}
+
+// Wait blocks until job id stopped running or ctx is done, and returns its final state.
+// Jobs running in this process are awaited directly, others by polling the store. When the final
+// state of a job run in this process could not be saved, it is returned with the error.
+func (ds *DeepSearch) Wait(ctx context.Context, id string) (*DeepSearchState, error) {
+	ds.mu.Lock()
+	run, ok := ds.running[id]
+	ds.mu.Unlock()
+	if ok {
+		select {
+		case <-run.done:
+			if run.saveErr != nil {
+				return run.state, run.saveErr
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	for {
+		state, err := ds.load(ctx, id)
+		if err != nil || state.Done() {
+			return state, err
+		}
+
+		if err := ds.clock.Sleep(ctx, ds.pollInterval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Cancel stops job id when it runs in this process, saving it as StatusCanceled.
+func (ds *DeepSearch) Cancel(id string) error {
+	ds.mu.Lock()
+	run, ok := ds.running[id]
+	ds.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%s: %w", id, ErrNotFound)
+	}
+
+	run.cancel()
+	<-run.done
+
+	return nil
+}
+
+// reserve registers a run of job id, unless one is registered already. Registering before the job
+// state is loaded keeps concurrent Resume calls from starting the same job twice.
+func (ds *DeepSearch) reserve(ctx context.Context, id string) (*deepSearchRun, bool) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if _, ok := ds.running[id]; ok {
+		return nil, false
+	}
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	run := &deepSearchRun{ctx: ctx, cancel: cancel, done: make(chan struct{})}
+	ds.running[id] = run
+
+	return run, true
+}
+
+// release unregisters the run of job id, once it ended or when it is not started after all.
+func (ds *DeepSearch) release(id string, run *deepSearchRun) {
+	ds.mu.Lock()
+	delete(ds.running, id)
+	ds.mu.Unlock()
+
+	run.cancel()
+	close(run.done)
+}
+
+// start runs the reserved job in the background.
+func (ds *DeepSearch) start(run *deepSearchRun, state *DeepSearchState) {
+	go func() {
+		defer ds.release(state.ID, run)
+
+		run.state, run.saveErr = state, ds.run(run.ctx, state)
+	}()
+}
+
+// run runs the job until it stopped, returning the error saving its final state.
+func (ds *DeepSearch) run(ctx context.Context, state *DeepSearchState) error {
+	var answer strings.Builder
+	lastSave := ds.clock.Now()
+	err := ds.client.DeepSearchStream(ctx, state.Request, func(chunk *jina.DeepSearchResponse) error {
+		if chunk.Usage.TotalTokens > 0 {
+			state.Usage = chunk.Usage
+		}
+		// Reasoning steps are streamed as "think" deltas, only the answer is kept
+		if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Type != "think" {
+			answer.WriteString(chunk.Choices[0].Delta.Content)
+		}
+		if ds.clock.Now().Sub(lastSave) < ds.progressInterval {
+			return nil
+		}
+
+		lastSave = ds.clock.Now()
+		state.Answer = answer.String()
+		return ds.save(ctx, state)
+	}, jina.WithDeepSearchActivity(func(activity jina.DeepSearchActivity) {
+		state.Queries = activity.Queries
+		state.VisitedURLs = activity.VisitedURLs
+		state.ReadURLs = activity.ReadURLs
+	}))
+
+	state.Answer = answer.String()
+	switch {
+	case err == nil:
+		state.Status = StatusCompleted
+	case errors.Is(err, context.Canceled):
+		state.Status = StatusCanceled
+	default:
+		state.Status = StatusFailed
+		state.Error = err.Error()
+	}
+
+	// Save with a fresh context so the final state is recorded even when the job was cancelled.
+	// A failed save is returned by Wait in this process, others keep seeing the job as running.
+	return ds.save(context.WithoutCancel(ctx), state)
+}
+
+func (ds *DeepSearch) save(ctx context.Context, state *DeepSearchState) error {
+	state.UpdatedAt = ds.clock.Now()
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal job state: %w", err)
+	}
+	if err := ds.store.Save(ctx, state.ID, data); err != nil {
+		return fmt.Errorf("save job state: %w", err)
+	}
+
+	return nil
+}
+
+func (ds *DeepSearch) load(ctx context.Context, id string) (*DeepSearchState, error) {
+	data, err := ds.store.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var state DeepSearchState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("unmarshal job state: %w", err)
+	}
+
+	return &state, nil
+}
+
+func newJobID() (string, error) {
+	var b [12]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("generate job ID: %w", err)
+	}
+
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,295 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fritzkeyzer/gojina"
+)
+
+// Status is the lifecycle stage of a job.
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed" // Resume retries the failed work
+)
+
+// Sink receives the embedding of the input at index. It is called once per input of every successful
+// batch, possibly concurrently. Inputs of a batch that was interrupted before its state was saved are
+// delivered again on Resume, so sinks should be idempotent (e.g. upserts keyed by index).
+type Sink func(ctx context.Context, index int, embedding []float32) error
+
+// EmbeddingsOption configures Embeddings.
+type EmbeddingsOption func(*Embeddings)
+
+// WithBatchSize sets the number of inputs per Embeddings call. Defaults to 128.
+func WithBatchSize(n int) EmbeddingsOption {
+	return func(e *Embeddings) {
+		e.batchSize = n
+	}
+}
+
+// WithConcurrency sets the number of batches embedded at once. Defaults to 4.
+func WithConcurrency(n int) EmbeddingsOption {
+	return func(e *Embeddings) {
+		e.concurrency = n
+	}
+}
+
+// WithRetries retries failed batches up to n times within a run, see jina.WithBatchRetries.
+func WithRetries(n int, backoff time.Duration) EmbeddingsOption {
+	return func(e *Embeddings) {
+		e.retries = n
+		e.backoff = backoff
+	}
+}
+
+// WithEmbeddingsClock replaces the wall clock used for job timestamps and retry backoff.
+// Defaults to jina.SystemClock.
+func WithEmbeddingsClock(clock jina.Clock) EmbeddingsOption {
+	return func(e *Embeddings) {
+		e.clock = clock
+	}
+}
+
+// Embeddings runs resumable embedding jobs over large corpora.
+type Embeddings struct {
+	client *jina.Client
+	store  Store
+	sink   Sink
+
+	batchSize   int
+	concurrency int
+	retries     int
+	backoff     time.Duration
+	clock       jina.Clock
+}
+
+// NewEmbeddings returns a job runner embedding with client, persisting job state to store
+// and delivering embeddings to sink.
+func NewEmbeddings(client *jina.Client, store Store, sink Sink, opts ...EmbeddingsOption) *Embeddings {
+	e := &Embeddings{
+		client:      client,
+		store:       store,
+		sink:        sink,
+		batchSize:   128,
+		concurrency: 4,
+		clock:       jina.SystemClock,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	e.batchSize = max(e.batchSize, 1)
+
+	return e
+}
+
+// EmbeddingsState is the persisted state of an embedding job.
+type EmbeddingsState struct {
+	ID      string                 `json:"id"`
+	Status  Status                 `json:"status"`
+	Request jina.EmbeddingsRequest `json:"request"` // Request parameters, without the inputs
+	Inputs  []Input                `json:"inputs"`
+
+	BatchSize int            `json:"batch_size"`
+	Done      []bool         `json:"done"` // Per batch
+	Failures  map[int]string `json:"failures,omitempty"`
+	Usage     jina.Usage     `json:"usage"`
+
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Input is the persisted form of a jina.EmbeddingInput.
+type Input struct {
+	Text  string `json:"text,omitempty"`
+	Image string `json:"image,omitempty"`
+	PDF   string `json:"pdf,omitempty"`
+}
+
+// Progress summarizes a job, see Embeddings.Status.
+type Progress struct {
+	ID       string
+	Status   Status
+	Total    int // Inputs in the job
+	Done     int // Inputs embedded
+	Pending  int // Inputs not embedded yet, including failed ones
+	Failed   int // Inputs of batches that failed in the last run
+	Failures map[int]string
+	Usage    jina.Usage
+
+	StartedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Start creates job id embedding req.Input with the parameters of req, and runs it until every batch
+// was attempted or ctx is done. It fails if a job with the ID already exists, use Resume to continue it.
+func (e *Embeddings) Start(ctx context.Context, id string, req jina.EmbeddingsRequest) error {
+	if _, err := e.store.Load(ctx, id); err == nil {
+		return fmt.Errorf("job %s already exists", id)
+	} else if !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("load job: %w", err)
+	}
+
+	now := e.clock.Now()
+	state := &EmbeddingsState{
+		ID:        id,
+		Status:    StatusRunning,
+		Request:   req,
+		BatchSize: e.batchSize,
+		Done:      make([]bool, (len(req.Input)+e.batchSize-1)/e.batchSize),
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+	state.Request.Input = nil
+	for _, input := range req.Input {
+		state.Inputs = append(state.Inputs, Input{Text: input.Text, Image: input.Image, PDF: input.PDF})
+	}
+	if err := e.save(ctx, state); err != nil {
+		return err
+	}
+
+	return e.run(ctx, state)
+}
+
+// Resume continues job id, embedding the pending and failed batches.
+func (e *Embeddings) Resume(ctx context.Context, id string) error {
+	state, err := e.load(ctx, id)
+	if err != nil {
+		return err
+	}
+	if state.Status == StatusCompleted {
+		return nil
+	}
+	state.Status = StatusRunning
+	state.Failures = nil
+
+	return e.run(ctx, state)
+}
+
+// Status reports the progress of job id as last saved, also while it runs in another process.
+func (e *Embeddings) Status(ctx context.Context, id string) (*Progress, error) {
+	state, err := e.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &Progress{
+		ID:        state.ID,
+		Status:    state.Status,
+		Total:     len(state.Inputs),
+		Failures:  state.Failures,
+		Usage:     state.Usage,
+		StartedAt: state.StartedAt,
+		UpdatedAt: state.UpdatedAt,
+	}
+	for batch, done := range state.Done {
+		size := state.batchLen(batch)
+		if done {
+			progress.Done += size
+		}
+		if _, failed := state.Failures[batch]; failed {
+			progress.Failed += size
+		}
+	}
+	progress.Pending = progress.Total - progress.Done
+
+	return progress, nil
+}
+
+func (e *Embeddings) run(ctx context.Context, state *EmbeddingsState) error {
+	var pending []int
+	for batch, done := range state.Done {
+		if !done {
+			pending = append(pending, batch)
+		}
+	}
+
+	var mu sync.Mutex
+	results, batchErr := jina.Batch(ctx, pending, func(ctx context.Context, batch int) (struct{}, error) {
+		req := state.Request
+		start := batch * state.BatchSize
+		for _, input := range state.Inputs[start : start+state.batchLen(batch)] {
+			req.Input = append(req.Input, jina.EmbeddingInput{Text: input.Text, Image: input.Image, PDF: input.PDF})
+		}
+
+		resp, err := e.client.Embeddings(ctx, req)
+		if err != nil {
+			return struct{}{}, err
+		}
+		for _, data := range resp.Data {
+			if err := e.sink(ctx, start+data.Index, data.Embedding); err != nil {
+				return struct{}{}, fmt.Errorf("sink: %w", err)
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		state.Done[batch] = true
+		state.Usage.TotalTokens += resp.Usage.TotalTokens
+		state.Usage.PromptTokens += resp.Usage.PromptTokens
+		return struct{}{}, e.save(ctx, state)
+	}, jina.WithBatchConcurrency(e.concurrency), jina.WithBatchRetries(e.retries, e.backoff), jina.WithBatchClock(e.clock))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	state.Status = StatusCompleted
+	for _, result := range results {
+		if result.Err == nil {
+			continue
+		}
+		state.Status = StatusFailed
+		if state.Failures == nil {
+			state.Failures = map[int]string{}
+		}
+		state.Failures[pending[result.Index]] = result.Err.Error()
+	}
+
+	// Save with a fresh context so the final state is recorded even when ctx was cancelled
+	if err := e.save(context.WithoutCancel(ctx), state); err != nil {
+		return err
+	}
+	if batchErr != nil {
+		return fmt.Errorf("job %s: %w", state.ID, batchErr)
+	}
+
+	return nil
+}
+
+func (s *EmbeddingsState) batchLen(batch int) int {
+	return min(s.BatchSize, len(s.Inputs)-batch*s.BatchSize)
+}
+
+func (e *Embeddings) save(ctx context.Context, state *EmbeddingsState) error {
+	state.UpdatedAt = e.clock.Now()
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal job state: %w", err)
+	}
+	if err := e.store.Save(ctx, state.ID, data); err != nil {
+		return fmt.Errorf("save job state: %w", err)
+	}
+
+	return nil
+}
+
+func (e *Embeddings) load(ctx context.Context, id string) (*EmbeddingsState, error) {
+	data, err := e.store.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var state EmbeddingsState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("unmarshal job state: %w", err)
+	}
+
+	return &state, nil
+}
@@ -0,0 +1,113 @@
+// Package jobs runs long Jina workloads as resumable jobs whose state is persisted to a Store,
+// so runs survive restarts and can be observed from another process.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned by stores and jobs when no job with the given ID exists.
+var ErrNotFound = errors.New("job not found")
+
+// Store persists encoded job states by job ID. Implementations must be safe for concurrent use.
+type Store interface {
+	Save(ctx context.Context, id string, state []byte) error
+
+	// Load returns the state saved for id, or an error wrapping ErrNotFound.
+	Load(ctx context.Context, id string) ([]byte, error)
+}
+
+// MemoryStore keeps job states in memory. Useful for tests and for observing jobs within one process.
+type MemoryStore struct {
+	mu     sync.Mutex
+	states map[string][]byte
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{states: make(map[string][]byte)}
+}
+
+func (s *MemoryStore) Save(_ context.Context, id string, state []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.states[id] = append([]byte(nil), state...)
+	return nil
+}
+
+func (s *MemoryStore) Load(_ context.Context, id string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[id]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", id, ErrNotFound)
+	}
+	return append([]byte(nil), state...), nil
+}
+
+// FileStore keeps each job state in a JSON file named after the job ID in a directory.
+// Files are replaced atomically, so a reader never observes a partially written state.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a FileStore writing to dir, creating it if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create store directory: %w", err)
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Save(_ context.Context, id string, state []byte) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(state); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FileStore) Load(_ context.Context, id string) ([]byte, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", id, ErrNotFound)
+	}
+	return state, err
+}
+
+func (s *FileStore) path(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return "", fmt.Errorf("invalid job ID %q", id)
+	}
+
+	return filepath.Join(s.dir, id+".json"), nil
+}
@@ -0,0 +1,60 @@
+package jina
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// jsonKind names the kind of the JSON value in data, for error messages.
+func jsonKind(data []byte) string {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return "empty value"
+	}
+	switch data[0] {
+	case '"':
+		return "string"
+	case '[':
+		return "array"
+	case '{':
+		return "object"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	default:
+		return "number"
+	}
+}
+
+// isJSONNull reports whether data is the JSON null literal.
+func isJSONNull(data []byte) bool {
+	return string(bytes.TrimSpace(data)) == "null"
+}
+
+// snippet returns data shortened for inclusion in error messages.
+func snippet(data []byte) string {
+	const maxLen = 120
+	if len(data) <= maxLen {
+		return string(data)
+	}
+	return fmt.Sprintf("%s... (%d bytes)", data[:maxLen], len(data))
+}
+
+// StreamChunkError is returned by streaming calls when an event of the stream cannot be decoded.
+// It identifies the event so that a single malformed chunk can be told apart from a broken stream.
+type StreamChunkError struct {
+	Endpoint Endpoint
+	Index    int    // Position of the event in the stream, starting at 0
+	Event    string // Server-sent event name, empty for unnamed events
+	Data     []byte // Undecoded event payload
+	Err      error
+}
+
+func (e *StreamChunkError) Error() string {
+	return fmt.Sprintf("%s stream: decode chunk %d: %v: %s", e.Endpoint, e.Index, e.Err, snippet(e.Data))
+}
+
+func (e *StreamChunkError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,221 @@
+package jina
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// KeySelection is the strategy used to pick a key from the pool configured with WithAPIKeys.
+type KeySelection int
+
+const (
+	// KeySelectionRoundRobin cycles through the keys in order.
+	KeySelectionRoundRobin KeySelection = iota
+
+	// KeySelectionLeastThrottled picks the key that was throttled least recently,
+	// skipping keys that are still inside a rate-limit window while others are not.
+	KeySelectionLeastThrottled
+
+	// KeySelectionWeighted spreads calls across the keys in proportion to their weights, see
+	// WithWeightedAPIKeys, skipping keys that are still inside a rate-limit window while others are not.
+	KeySelectionWeighted
+)
+
+// WithAPIKeys spreads calls across several API keys, e.g. one per project, to raise the
+// effective rate limit. Throttling and rate-limit headers are tracked per key, see Client.KeyStats.
+// A key set with WithCallAPIKey still takes precedence for that call.
+func WithAPIKeys(selection KeySelection, keys ...string) Option {
+	return func(cfg *config) {
+		cfg.APIKeys = keys
+		cfg.APIKeyWeights = nil
+		cfg.KeySelection = selection
+	}
+}
+
+// WeightedKey is an API key and its share of the calls, see WithWeightedAPIKeys.
+type WeightedKey struct {
+	Key    string
+	Weight int // Relative share of the calls, values below 1 count as 1
+}
+
+// WithWeightedAPIKeys spreads calls across keys in proportion to their weights, e.g. to match the
+// rate limits of keys on different plans:
+//
+//	jina.WithWeightedAPIKeys(
+//		jina.WeightedKey{Key: premiumKey, Weight: 5},
+//		jina.WeightedKey{Key: freeKey, Weight: 1},
+//	)
+//
+// Calls are interleaved rather than sent in runs to the same key. Like with WithAPIKeys, throttled keys
+// are skipped and usage is tracked per key.
+func WithWeightedAPIKeys(keys ...WeightedKey) Option {
+	return func(cfg *config) {
+		cfg.APIKeys = make([]string, len(keys))
+		cfg.APIKeyWeights = make([]int, len(keys))
+		for i, key := range keys {
+			cfg.APIKeys[i] = key.Key
+			cfg.APIKeyWeights[i] = max(key.Weight, 1)
+		}
+		cfg.KeySelection = KeySelectionWeighted
+	}
+}
+
+// KeyStats is the usage tracked for one key of the pool.
+type KeyStats struct {
+	Index         int       // Position of the key in WithAPIKeys
+	Requests      int       // Responses received with the key
+	Throttled     int       // 429 responses received with the key
+	LastThrottled time.Time // Zero if the key was never throttled
+	RateLimit     RateLimit // From the latest response carrying rate-limit headers
+}
+
+// KeyStats reports the tracked usage of every key configured with WithAPIKeys, in order.
+// It returns nil when no key pool is configured.
+func (cl *Client) KeyStats() []KeyStats {
+	if cl.keys == nil {
+		return nil
+	}
+
+	cl.keys.mu.Lock()
+	defer cl.keys.mu.Unlock()
+
+	stats := make([]KeyStats, len(cl.keys.keys))
+	for i, key := range cl.keys.keys {
+		stats[i] = key.stats
+	}
+
+	return stats
+}
+
+type keyPool struct {
+	selection KeySelection
+	clock     Clock
+
+	mu   sync.Mutex
+	keys []*pooledKey
+	next int
+}
+
+type pooledKey struct {
+	key            string
+	throttledUntil time.Time
+	stats          KeyStats
+
+	// Smooth weighted round-robin state of KeySelectionWeighted
+	weight, current int
+}
+
+func newKeyPool(selection KeySelection, keys []string, weights []int, clock Clock) *keyPool {
+	pool := &keyPool{selection: selection, clock: clock}
+	for i, key := range keys {
+		weight := 1
+		if i < len(weights) {
+			weight = weights[i]
+		}
+		pool.keys = append(pool.keys, &pooledKey{key: key, stats: KeyStats{Index: i}, weight: weight})
+	}
+
+	return pool
+}
+
+// pick returns the key to use for the next call.
+func (p *keyPool) pick() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.selection == KeySelectionWeighted {
+		return p.pickWeighted()
+	}
+
+	start := p.next
+	p.next = (p.next + 1) % len(p.keys)
+	if p.selection == KeySelectionRoundRobin {
+		return p.keys[start].key
+	}
+
+	// Ties are broken in round-robin order, so unthrottled keys still share the load
+	now := p.clock.Now()
+	var best *pooledKey
+	for i := range p.keys {
+		key := p.keys[(start+i)%len(p.keys)]
+		if best == nil || key.better(best, now) {
+			best = key
+		}
+	}
+
+	return best.key
+}
+
+// pickWeighted picks keys by smooth weighted round-robin: every candidate gains its weight on each
+// pick, the one ahead is picked and set back by the candidates' total weight. Throttled keys are no
+// candidates while others are not, so they do not get a run of calls after their window passed.
+// p.mu must be held.
+func (p *keyPool) pickWeighted() string {
+	now := p.clock.Now()
+	candidates := make([]*pooledKey, 0, len(p.keys))
+	for _, key := range p.keys {
+		if !now.Before(key.throttledUntil) {
+			candidates = append(candidates, key)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = p.keys
+	}
+
+	var best *pooledKey
+	total := 0
+	for _, key := range candidates {
+		key.current += key.weight
+		total += key.weight
+		if best == nil || key.current > best.current {
+			best = key
+		}
+	}
+	best.current -= total
+
+	return best.key
+}
+
+func (k *pooledKey) better(other *pooledKey, now time.Time) bool {
+	throttled, otherThrottled := now.Before(k.throttledUntil), now.Before(other.throttledUntil)
+	if throttled != otherThrottled {
+		return !throttled
+	}
+
+	return k.stats.LastThrottled.Before(other.stats.LastThrottled)
+}
+
+// observe records the response to a call made with the key selected for req.
+func (p *keyPool) observe(req *http.Request, resp *http.Response) {
+	token := callAPIKey(req)
+	if token == "" {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, key := range p.keys {
+		if key.key != token {
+			continue
+		}
+
+		now := p.clock.Now()
+		key.stats.Requests++
+		rateLimit := parseRateLimit(resp.Header, now)
+		if rateLimit != (RateLimit{}) {
+			key.stats.RateLimit = rateLimit
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			key.stats.Throttled++
+			key.stats.LastThrottled = now
+			wait, ok := parseRetryAfter(resp.Header, now)
+			if !ok {
+				wait = rateLimit.Reset
+			}
+			key.throttledUntil = now.Add(wait)
+		}
+		return
+	}
+}
@@ -0,0 +1,218 @@
+package jina
+
+import (
+	"strings"
+	"unicode"
+)
+
+// LanguageDetector returns the ISO 639-1 code of the language text is written in, or false when it
+// cannot tell. See WithLocaleDetection.
+type LanguageDetector func(text string) (language string, ok bool)
+
+// WithLocaleDetection makes Search detect the language of queries sent without LanguageCode and
+// CountryCode, and set hl, gl and, when reading full content, the browser locale to match (see
+// SearchRequest.SetLocale). A nil detector uses DetectLanguage. Queries the detector cannot tell are
+// sent unchanged.
+func WithLocaleDetection(detector LanguageDetector) Option {
+	return func(cfg *config) {
+		if detector == nil {
+			detector = DetectLanguage
+		}
+		cfg.LocaleDetector = detector
+	}
+}
+
+// Locale is a language and the country it is most commonly searched from.
+type Locale struct {
+	Language string // ISO 639-1 code
+	Country  string // ISO 3166-1 alpha-2 code, may be empty
+}
+
+// BrowserLocale formats the locale as a BCP 47 tag for ReaderRequest.BrowserLocale, e.g. "de-DE".
+func (l Locale) BrowserLocale() string {
+	if l.Country == "" {
+		return l.Language
+	}
+
+	return l.Language + "-" + strings.ToUpper(l.Country)
+}
+
+// LanguageCountries maps languages to the country used as gl when a locale is detected.
+var LanguageCountries = map[string]string{
+	"ar": "sa",
+	"da": "dk",
+	"de": "de",
+	"el": "gr",
+	"en": "us",
+	"es": "es",
+	"fr": "fr",
+	"he": "il",
+	"hi": "in",
+	"id": "id",
+	"it": "it",
+	"ja": "jp",
+	"ko": "kr",
+	"nl": "nl",
+	"pl": "pl",
+	"pt": "br",
+	"ru": "ru",
+	"sv": "se",
+	"th": "th",
+	"tr": "tr",
+	"uk": "ua",
+	"zh": "cn",
+}
+
+// DetectLocale detects the language of text with detector, or DetectLanguage when nil, and pairs it
+// with its country from LanguageCountries.
+func DetectLocale(text string, detector LanguageDetector) (Locale, bool) {
+	if detector == nil {
+		detector = DetectLanguage
+	}
+	language, ok := detector(text)
+	if !ok {
+		return Locale{}, false
+	}
+
+	return Locale{Language: language, Country: LanguageCountries[language]}, true
+}
+
+// SetLocale sets the LanguageCode and CountryCode of the request, and its browser Locale when reading
+// full content, keeping any of them already set.
+func (r *SearchRequest) SetLocale(l Locale) {
+	if r.LanguageCode == "" {
+		r.LanguageCode = l.Language
+	}
+	if r.CountryCode == "" {
+		r.CountryCode = l.Country
+	}
+	if r.ReadFullContent && r.Locale == "" {
+		r.Locale = l.BrowserLocale()
+	}
+}
+
+// SetLocale sets the BrowserLocale of the request unless already set, e.g. to the locale detected
+// for the search query whose results are read.
+func (r *ReaderRequest) SetLocale(l Locale) {
+	if r.BrowserLocale == "" {
+		r.BrowserLocale = l.BrowserLocale()
+	}
+}
+
+// scriptLanguages maps scripts written in by a single common language to that language.
+var scriptLanguages = []struct {
+	script   *unicode.RangeTable
+	language string
+}{
+	{unicode.Hangul, "ko"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Greek, "el"},
+	{unicode.Thai, "th"},
+	{unicode.Devanagari, "hi"},
+}
+
+// stopwords holds frequent function words of languages written in Latin script.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "in", "is", "for", "how", "what", "with", "on", "best", "near", "are", "why"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "mit", "für", "wie", "was", "ein", "eine", "auf", "den", "von"},
+	"fr": {"le", "la", "les", "et", "des", "est", "pour", "une", "dans", "avec", "comment", "du", "au", "sur", "qui"},
+	"es": {"el", "los", "las", "y", "es", "para", "una", "con", "cómo", "qué", "por", "del", "como", "más", "mejor"},
+	"it": {"il", "di", "che", "è", "per", "una", "con", "come", "sono", "gli", "della", "del", "dove", "cosa", "nel"},
+	"pt": {"o", "os", "as", "e", "é", "para", "uma", "com", "como", "não", "do", "da", "em", "melhor", "onde"},
+	"nl": {"de", "het", "een", "en", "is", "van", "voor", "met", "hoe", "wat", "niet", "op", "zijn", "waar", "beste"},
+	"sv": {"och", "att", "är", "för", "med", "som", "hur", "vad", "inte", "på", "av", "en", "ett", "bästa", "var"},
+	"pl": {"i", "w", "na", "jest", "się", "z", "do", "jak", "co", "nie", "dla", "czy", "od", "po", "najlepszy"},
+	"tr": {"ve", "bir", "bu", "için", "ile", "nasıl", "ne", "en", "mi", "da", "de", "gibi", "nedir", "neden", "nerede"},
+	"id": {"dan", "yang", "di", "untuk", "dengan", "apa", "cara", "ini", "itu", "dari", "tidak", "bagaimana", "terbaik", "ada", "ke"},
+}
+
+// DetectLanguage is a dependency-free LanguageDetector for search queries. It recognizes languages by
+// their script, and common Latin-script languages by their function words, so it cannot tell short
+// queries made of names and nouns only. Plug in a statistical detector for better coverage.
+func DetectLanguage(text string) (string, bool) {
+	var letters, latin, han, kana, cyrillic int
+	scripts := make([]int, len(scriptLanguages))
+	ukrainian := false
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			kana++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+			ukrainian = ukrainian || strings.ContainsRune("іїєґІЇЄҐ", r)
+		default:
+			for i, sl := range scriptLanguages {
+				if unicode.Is(sl.script, r) {
+					scripts[i]++
+					break
+				}
+			}
+		}
+	}
+	if letters == 0 {
+		return "", false
+	}
+
+	// Japanese mixes kana with Han characters, any kana tells it apart from Chinese
+	switch {
+	case kana > 0 && kana+han >= letters/2:
+		return "ja", true
+	case han >= letters/2:
+		return "zh", true
+	case cyrillic >= letters/2 && ukrainian:
+		return "uk", true
+	case cyrillic >= letters/2:
+		return "ru", true
+	}
+	for i, count := range scripts {
+		if count >= letters/2 {
+			return scriptLanguages[i].language, true
+		}
+	}
+	if latin < letters/2 {
+		return "", false
+	}
+
+	return detectLatinLanguage(text)
+}
+
+// detectLatinLanguage picks the language with the most stopword hits, requiring a clear winner.
+func detectLatinLanguage(text string) (string, bool) {
+	hits := make(map[string]int)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		for language, words := range stopwords {
+			for _, stopword := range words {
+				if word == stopword {
+					hits[language]++
+					break
+				}
+			}
+		}
+	}
+
+	best, bestHits, tied := "", 0, false
+	for language, n := range hits {
+		switch {
+		case n > bestHits:
+			best, bestHits, tied = language, n, false
+		case n == bestHits:
+			tied = true
+		}
+	}
+	if bestHits == 0 || tied {
+		return "", false
+	}
+
+	return best, true
+}
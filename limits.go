@@ -0,0 +1,154 @@
+package jina
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// Limits are checked client-side before a request is sent, so oversized payloads fail fast
+// instead of costing a round trip that ends in a 413. A zero value disables the check.
+type Limits struct {
+	// MaxEmbeddingInputs is the maximum number of inputs per Embeddings call.
+	MaxEmbeddingInputs int
+
+	// MaxRerankDocuments is the maximum number of documents per Rerank call.
+	MaxRerankDocuments int
+
+	// MaxImageBytes is the maximum decoded size of a base64 encoded image.
+	// Images passed by URL are not checked.
+	MaxImageBytes int
+
+	// MaxVLMImages is the maximum number of images in a single VLM message.
+	MaxVLMImages int
+
+	// MaxRequestBytes is the maximum size of the encoded JSON request body of any call.
+	MaxRequestBytes int
+}
+
+// DefaultLimits follow the limits documented by Jina. Override them with WithLimits
+// if your account or deployment allows more.
+var DefaultLimits = Limits{
+	MaxEmbeddingInputs: 2048,
+	MaxRerankDocuments: 2048,
+	MaxImageBytes:      10 << 20,
+	MaxVLMImages:       10,
+	MaxRequestBytes:    64 << 20,
+}
+
+// WithLimits replaces the client-side limits checked before each request. Use Limits{} to disable all checks.
+func WithLimits(limits Limits) Option {
+	return func(cfg *config) {
+		cfg.Limits = limits
+	}
+}
+
+// LimitError is returned when a request exceeds a client-side limit before it is sent.
+type LimitError struct {
+	Field string // The request field that exceeded the limit
+	Limit int
+	Got   int
+	Hint  string // What to do about it
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s exceeds limit: %d > %d, %s", e.Field, e.Got, e.Limit, e.Hint)
+}
+
+func (l Limits) checkEmbeddings(req EmbeddingsRequest) error {
+	if l.MaxEmbeddingInputs > 0 && len(req.Input) > l.MaxEmbeddingInputs {
+		return &LimitError{Field: "input", Limit: l.MaxEmbeddingInputs, Got: len(req.Input), Hint: "split the inputs into smaller batches"}
+	}
+	for i, input := range req.Input {
+		if err := l.checkImage(fmt.Sprintf("input[%d].image", i), input.Image); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l Limits) checkRerank(req RerankRequest) error {
+	numDocuments := max(len(req.Documents), len(req.DocumentsInput))
+	if l.MaxRerankDocuments > 0 && numDocuments > l.MaxRerankDocuments {
+		return &LimitError{Field: "documents", Limit: l.MaxRerankDocuments, Got: numDocuments, Hint: "rerank the documents in smaller batches and merge the results"}
+	}
+	if req.QueryInput != nil {
+		if err := l.checkImage("query.image", req.QueryInput.Image); err != nil {
+			return err
+		}
+	}
+	for i, document := range req.DocumentsInput {
+		if err := l.checkImage(fmt.Sprintf("documents[%d].image", i), document.Image); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l Limits) checkClassification(req ClassificationRequest) error {
+	for i, input := range req.Input {
+		if err := l.checkImage(fmt.Sprintf("input[%d].image", i), input.Image); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l Limits) checkMessages(messages []VLMMessage) error {
+	for i, message := range messages {
+		for j, part := range message.Content.Parts {
+			if part.ImageURL == nil {
+				continue
+			}
+			if err := l.checkImage(fmt.Sprintf("messages[%d].content[%d].image_url", i, j), part.ImageURL.URL); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (l Limits) checkVLM(messages []VLMMessage) error {
+	for i, message := range messages {
+		images := 0
+		for _, part := range message.Content.Parts {
+			if part.ImageURL != nil {
+				images++
+			}
+		}
+		if l.MaxVLMImages > 0 && images > l.MaxVLMImages {
+			return &LimitError{Field: fmt.Sprintf("messages[%d] images", i), Limit: l.MaxVLMImages, Got: images, Hint: "compare fewer images per message"}
+		}
+	}
+
+	return l.checkMessages(messages)
+}
+
+// checkImage checks the decoded size of a base64 image or data URI. URLs are left to the API.
+func (l Limits) checkImage(field, image string) error {
+	if l.MaxImageBytes <= 0 || image == "" || strings.HasPrefix(image, "http://") || strings.HasPrefix(image, "https://") {
+		return nil
+	}
+	if i := strings.Index(image, ";base64,"); strings.HasPrefix(image, "data:") && i >= 0 {
+		image = image[i+len(";base64,"):]
+	}
+
+	size := base64.StdEncoding.DecodedLen(len(image))
+	if size > l.MaxImageBytes {
+		return &LimitError{Field: field, Limit: l.MaxImageBytes, Got: size, Hint: "downscale or recompress the image, or pass it by URL"}
+	}
+
+	return nil
+}
+
+func (l Limits) checkRequestBody(body *requestBody) error {
+	if l.MaxRequestBytes > 0 && body.len() > l.MaxRequestBytes {
+		return &LimitError{Field: "request body", Limit: l.MaxRequestBytes, Got: body.len(), Hint: "send fewer or smaller inputs per call"}
+	}
+
+	return nil
+}
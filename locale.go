@@ -0,0 +1,121 @@
+package jina
+
+import (
+	"fmt"
+	"strings"
+)
+
+// countryCodeAliases are accepted besides the ISO 3166-1 codes, mapped to the country they stand for.
+var countryCodeAliases = map[string]string{
+	"uk": "gb", // Google accepts uk for the United Kingdom
+}
+
+// languageCodeAliases are legacy codes still accepted by search engines.
+var languageCodeAliases = map[string]string{
+	"iw": "he",
+	"jw": "jv",
+	"in": "id",
+}
+
+// LocaleError is returned when a search locale parameter is invalid, rather than sending a request
+// the search engine silently answers with results for another locale.
+type LocaleError struct {
+	Field  string // gl, hl or location
+	Value  string
+	Reason string
+}
+
+func (e *LocaleError) Error() string {
+	return fmt.Sprintf("invalid %s %q: %s", e.Field, e.Value, e.Reason)
+}
+
+// ValidateCountryCode checks that code is an ISO 3166-1 alpha-2 country code, as used by SearchRequest.CountryCode.
+// "uk" is accepted as an alias of "gb".
+func ValidateCountryCode(code string) error {
+	if _, ok := country(code); !ok {
+		return &LocaleError{Field: "gl", Value: code, Reason: "not an ISO 3166-1 alpha-2 country code"}
+	}
+
+	return nil
+}
+
+// ValidateLanguageCode checks that code is an ISO 639-1 language code, optionally followed by a
+// country subtag (e.g. "pt-br", "zh-TW"), as used by SearchRequest.LanguageCode.
+func ValidateLanguageCode(code string) error {
+	language, region, hasRegion := strings.Cut(strings.ToLower(code), "-")
+	if alias, ok := languageCodeAliases[language]; ok {
+		language = alias
+	}
+	if !languageCodes[language] {
+		return &LocaleError{Field: "hl", Value: code, Reason: "not an ISO 639-1 language code"}
+	}
+	if _, ok := country(region); hasRegion && !ok {
+		return &LocaleError{Field: "hl", Value: code, Reason: "region is not an ISO 3166-1 alpha-2 country code"}
+	}
+
+	return nil
+}
+
+// FormatLocation builds a SearchRequest.Location from its parts, e.g. FormatLocation("Austin", "Texas", "us")
+// returns "Austin,Texas,United States". City and region are optional.
+func FormatLocation(city, region, countryCode string) (string, error) {
+	code, ok := country(countryCode)
+	if !ok {
+		return "", &LocaleError{Field: "location", Value: countryCode, Reason: "not an ISO 3166-1 alpha-2 country code"}
+	}
+
+	var parts []string
+	for _, part := range []string{city, region} {
+		if part = strings.TrimSpace(part); part != "" {
+			if strings.Contains(part, ",") {
+				return "", &LocaleError{Field: "location", Value: part, Reason: "must not contain commas"}
+			}
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(append(parts, countryNames[code]), ","), nil
+}
+
+// ValidateLocale checks the CountryCode, LanguageCode and Location of the request, including
+// a Location naming a different country than CountryCode. Search calls it before sending.
+func (r SearchRequest) ValidateLocale() error {
+	if r.CountryCode != "" {
+		if err := ValidateCountryCode(r.CountryCode); err != nil {
+			return err
+		}
+	}
+	if r.LanguageCode != "" {
+		if err := ValidateLanguageCode(r.LanguageCode); err != nil {
+			return err
+		}
+	}
+	if r.Location == "" || r.CountryCode == "" {
+		return nil
+	}
+
+	parts := strings.Split(r.Location, ",")
+	last := strings.TrimSpace(parts[len(parts)-1])
+	for code, name := range countryNames {
+		if !strings.EqualFold(name, last) {
+			continue
+		}
+		if gl, _ := country(r.CountryCode); gl != code {
+			return &LocaleError{Field: "location", Value: r.Location, Reason: fmt.Sprintf("is in %s but gl is %q", name, r.CountryCode)}
+		}
+		break
+	}
+
+	return nil
+}
+
+// country resolves a country code or alias to its ISO 3166-1 code.
+func country(code string) (string, bool) {
+	code = strings.ToLower(code)
+	if alias, ok := countryCodeAliases[code]; ok {
+		code = alias
+	}
+	_, ok := countryNames[code]
+
+	return code, ok
+}
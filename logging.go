@@ -0,0 +1,59 @@
+package jina
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// WithLogger logs every attempt of every call at debug level: method, endpoint, status, latency,
+// attempt number and correlation ID, with credential headers and API keys redacted. The token usage
+// of successful calls is logged once it is reported, see UsageHook.
+func WithLogger(logger *slog.Logger) Option {
+	return func(cfg *config) {
+		cfg.Logger = logger
+	}
+}
+
+// logAttempt logs a single attempt of a call started at start.
+func (cl *Client) logAttempt(endpoint Endpoint, req *http.Request, attempt int, start time.Time, resp *http.Response, err error) {
+	logger := cl.cfg.Logger
+	if logger == nil || !logger.Enabled(req.Context(), slog.LevelDebug) {
+		return
+	}
+
+	secrets := requestSecrets(req)
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("endpoint", string(endpoint)),
+		slog.String("url", req.URL.Redacted()),
+		slog.Int("attempt", attempt),
+		slog.Duration("latency", cl.cfg.Clock.Now().Sub(start)),
+		slog.String("correlation_id", req.Header.Get(CorrelationHeader)),
+		slog.Any("headers", redactHeaders(req.Header, secrets)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", redactSecrets(err.Error(), secrets)))
+	} else {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+
+	logger.LogAttrs(req.Context(), slog.LevelDebug, "jina request", attrs...)
+}
+
+// logUsage logs the usage reported for a successful call.
+func (cl *Client) logUsage(ctx context.Context, endpoint Endpoint, model string, usage Usage) {
+	logger := cl.cfg.Logger
+	if logger == nil || !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "jina usage",
+		slog.String("endpoint", string(endpoint)),
+		slog.String("model", model),
+		slog.Int("total_tokens", usage.TotalTokens),
+		slog.Int("prompt_tokens", usage.PromptTokens),
+		slog.Int("completion_tokens", usage.CompletionTokens),
+	)
+}
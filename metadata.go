@@ -0,0 +1,256 @@
+package jina
+
+import (
+	"encoding/json"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// PageMetadata holds the OpenGraph tags and JSON-LD blocks of an HTML page.
+// See ReaderRequest.ParseMetadata and ParsePageMetadata.
+type PageMetadata struct {
+	OpenGraph OpenGraph
+
+	// JSONLD holds every JSON-LD item of the page, with @graph containers flattened.
+	JSONLD []json.RawMessage
+
+	// Typed views of the JSON-LD items of the common schema.org types.
+	Articles []Article
+	Products []Product
+	Events   []Event
+}
+
+// OpenGraph holds the og: meta tags of a page.
+type OpenGraph struct {
+	Title       string
+	Type        string
+	URL         string
+	Image       string
+	Description string
+	SiteName    string
+	Locale      string
+
+	// Tags holds every og:, article:, product: and twitter: tag by property name, including the above.
+	Tags map[string]string
+}
+
+// Article is a schema.org Article, NewsArticle, BlogPosting or similar item.
+type Article struct {
+	Type          string
+	Headline      string
+	Description   string
+	URL           string
+	Image         string
+	Authors       []string
+	Publisher     string
+	DatePublished string
+	DateModified  string
+}
+
+// Product is a schema.org Product item.
+type Product struct {
+	Name         string
+	Description  string
+	URL          string
+	Image        string
+	Brand        string
+	SKU          string
+	Price        string
+	Currency     string
+	Availability string
+}
+
+// Event is a schema.org Event item.
+type Event struct {
+	Type        string
+	Name        string
+	Description string
+	URL         string
+	Image       string
+	StartDate   string
+	EndDate     string
+	Location    string
+}
+
+var (
+	metaTagPattern   = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	attributePattern = regexp.MustCompile(`(?s)([a-zA-Z_:-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	jsonLDPattern    = regexp.MustCompile(`(?is)<script[^>]*type\s*=\s*["']?application/ld\+json["']?[^>]*>(.*?)</script>`)
+)
+
+var articleTypes = []string{"Article", "NewsArticle", "BlogPosting", "TechArticle", "ScholarlyArticle", "Report", "LiveBlogPosting"}
+
+// ParsePageMetadata extracts the OpenGraph tags and JSON-LD blocks of an HTML document.
+// Malformed JSON-LD blocks are skipped.
+func ParsePageMetadata(document string) *PageMetadata {
+	meta := &PageMetadata{OpenGraph: OpenGraph{Tags: map[string]string{}}}
+
+	for _, tag := range metaTagPattern.FindAllString(document, -1) {
+		attrs := map[string]string{}
+		for _, attr := range attributePattern.FindAllStringSubmatch(tag, -1) {
+			attrs[strings.ToLower(attr[1])] = html.UnescapeString(attr[2] + attr[3] + attr[4])
+		}
+		property := attrs["property"]
+		if property == "" {
+			property = attrs["name"]
+		}
+		property = strings.ToLower(property)
+		prefix, _, _ := strings.Cut(property, ":")
+		if (prefix == "og" || prefix == "article" || prefix == "product" || prefix == "twitter") && attrs["content"] != "" {
+			if _, ok := meta.OpenGraph.Tags[property]; !ok {
+				meta.OpenGraph.Tags[property] = attrs["content"]
+			}
+		}
+	}
+	tags := meta.OpenGraph.Tags
+	meta.OpenGraph.Title = tags["og:title"]
+	meta.OpenGraph.Type = tags["og:type"]
+	meta.OpenGraph.URL = tags["og:url"]
+	meta.OpenGraph.Image = tags["og:image"]
+	meta.OpenGraph.Description = tags["og:description"]
+	meta.OpenGraph.SiteName = tags["og:site_name"]
+	meta.OpenGraph.Locale = tags["og:locale"]
+
+	for _, match := range jsonLDPattern.FindAllStringSubmatch(document, -1) {
+		var block any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(match[1])), &block); err != nil {
+			continue
+		}
+		for _, item := range flattenJSONLD(block) {
+			raw, err := json.Marshal(item)
+			if err != nil {
+				continue
+			}
+			meta.JSONLD = append(meta.JSONLD, raw)
+			meta.addTyped(item)
+		}
+	}
+
+	return meta
+}
+
+func (m *PageMetadata) addTyped(item map[string]any) {
+	for _, typ := range ldStrings(item["@type"]) {
+		switch {
+		case slices.Contains(articleTypes, typ):
+			article := Article{
+				Type:          typ,
+				Headline:      ldString(item["headline"]),
+				Description:   ldString(item["description"]),
+				URL:           ldString(item["url"]),
+				Image:         ldString(item["image"]),
+				Authors:       ldStrings(item["author"]),
+				Publisher:     ldString(item["publisher"]),
+				DatePublished: ldString(item["datePublished"]),
+				DateModified:  ldString(item["dateModified"]),
+			}
+			if article.Headline == "" {
+				article.Headline = ldString(item["name"])
+			}
+			m.Articles = append(m.Articles, article)
+		case typ == "Product":
+			product := Product{
+				Name:        ldString(item["name"]),
+				Description: ldString(item["description"]),
+				URL:         ldString(item["url"]),
+				Image:       ldString(item["image"]),
+				Brand:       ldString(item["brand"]),
+				SKU:         ldString(item["sku"]),
+			}
+			if offers := ldObjects(item["offers"]); len(offers) > 0 {
+				product.Price = ldString(offers[0]["price"])
+				if product.Price == "" {
+					product.Price = ldString(offers[0]["lowPrice"])
+				}
+				product.Currency = ldString(offers[0]["priceCurrency"])
+				product.Availability = ldString(offers[0]["availability"])
+			}
+			m.Products = append(m.Products, product)
+		case strings.HasSuffix(typ, "Event"):
+			m.Events = append(m.Events, Event{
+				Type:        typ,
+				Name:        ldString(item["name"]),
+				Description: ldString(item["description"]),
+				URL:         ldString(item["url"]),
+				Image:       ldString(item["image"]),
+				StartDate:   ldString(item["startDate"]),
+				EndDate:     ldString(item["endDate"]),
+				Location:    ldString(item["location"]),
+			})
+		default:
+			continue
+		}
+		return
+	}
+}
+
+// flattenJSONLD returns the items of a JSON-LD block, which may be an object, an array or an @graph container.
+func flattenJSONLD(block any) []map[string]any {
+	var items []map[string]any
+	for _, object := range ldObjects(block) {
+		if graph, ok := object["@graph"]; ok {
+			items = append(items, flattenJSONLD(graph)...)
+			continue
+		}
+		items = append(items, object)
+	}
+
+	return items
+}
+
+func ldObjects(v any) []map[string]any {
+	switch v := v.(type) {
+	case map[string]any:
+		return []map[string]any{v}
+	case []any:
+		var objects []map[string]any
+		for _, item := range v {
+			objects = append(objects, ldObjects(item)...)
+		}
+		return objects
+	}
+
+	return nil
+}
+
+// ldString returns the string form of a JSON-LD value: strings and numbers as is,
+// objects by their name, url or @id, arrays by their first element.
+func ldString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(html.UnescapeString(v))
+	case float64:
+		raw, _ := json.Marshal(v)
+		return string(raw)
+	case map[string]any:
+		for _, key := range []string{"name", "url", "@id", "contentUrl"} {
+			if s := ldString(v[key]); s != "" {
+				return s
+			}
+		}
+	case []any:
+		if len(v) > 0 {
+			return ldString(v[0])
+		}
+	}
+
+	return ""
+}
+
+func ldStrings(v any) []string {
+	items, ok := v.([]any)
+	if !ok {
+		items = []any{v}
+	}
+
+	var values []string
+	for _, item := range items {
+		if s := ldString(item); s != "" {
+			values = append(values, s)
+		}
+	}
+
+	return values
+}
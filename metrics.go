@@ -0,0 +1,92 @@
+package jina
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// MetricsHook observes every call sent to the API, e.g. to export request counts, latencies and
+// token usage to Prometheus or StatsD. Its methods are called synchronously on the calling goroutine
+// and must be safe for concurrent use.
+type MetricsHook interface {
+	// OnRequestStart is called before the request is sent, queued or throttled.
+	OnRequestStart(ctx context.Context, endpoint Endpoint)
+
+	// OnRequestEnd is called once the response is read or the call failed, retries included.
+	OnRequestEnd(ctx context.Context, metrics RequestMetrics)
+}
+
+// RequestMetrics describes a completed call, see MetricsHook.
+type RequestMetrics struct {
+	Endpoint   Endpoint
+	Duration   time.Duration // From OnRequestStart until the response was read
+	StatusCode int           // Status of the last attempt, zero when no response was received
+	Usage      Usage         // Tokens reported by the response, zero for failed calls
+	Err        error
+}
+
+// WithMetricsHook registers a hook observing the start and end of every call. Streaming calls end
+// once the stream is consumed.
+func WithMetricsHook(hook MetricsHook) Option {
+	return func(cfg *config) {
+		cfg.MetricsHooks = append(cfg.MetricsHooks, hook)
+	}
+}
+
+// startMetrics records the start of a call, notifies the metrics hooks and returns the function
+// reporting its end.
+func (cl *Client) startMetrics(ctx context.Context, endpoint Endpoint, call *callConfig) func(err error) {
+	call.started = cl.cfg.Clock.Now()
+	if len(cl.cfg.MetricsHooks) == 0 {
+		return func(error) {}
+	}
+
+	for _, hook := range cl.cfg.MetricsHooks {
+		hook.OnRequestStart(ctx, endpoint)
+	}
+
+	return func(err error) {
+		metrics := RequestMetrics{
+			Endpoint:   endpoint,
+			Duration:   cl.cfg.Clock.Now().Sub(call.started),
+			StatusCode: call.statusCode,
+			Err:        err,
+		}
+		if err == nil {
+			metrics.Usage = call.usage
+		}
+		for _, hook := range cl.cfg.MetricsHooks {
+			hook.OnRequestEnd(ctx, metrics)
+		}
+	}
+}
+
+// setResponseMeta sets the Meta field of a decoded response, if it has one.
+func setResponseMeta(out any, meta Meta) {
+	value := reflect.ValueOf(out)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return
+	}
+	if field := value.Elem().FieldByName("Meta"); field.IsValid() && field.Type() == reflect.TypeFor[Meta]() {
+		field.Set(reflect.ValueOf(meta))
+	}
+}
+
+// responseUsage returns the Usage field of a decoded response, or zero when it has none.
+func responseUsage(out any) Usage {
+	value := reflect.ValueOf(out)
+	if value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return Usage{}
+	}
+	field := value.FieldByName("Usage")
+	if !field.IsValid() {
+		return Usage{}
+	}
+	usage, _ := field.Interface().(Usage)
+
+	return usage
+}
@@ -0,0 +1,38 @@
+package jina
+
+// WithDefaultEmbeddingModel sets the model used by Embeddings calls that leave Model empty,
+// so application code can stay model-agnostic and the model can be changed through configuration.
+func WithDefaultEmbeddingModel(model EmbeddingModel) Option {
+	return func(cfg *config) {
+		cfg.DefaultEmbeddingModel = model
+	}
+}
+
+// WithDefaultReranker sets the model used by Rerank calls that leave Model empty.
+func WithDefaultReranker(model RerankerModel) Option {
+	return func(cfg *config) {
+		cfg.DefaultRerankerModel = model
+	}
+}
+
+// WithDefaultClassificationModel sets the model used by Classify calls that leave both Model and ClassifierID empty.
+func WithDefaultClassificationModel(model ClassificationModel) Option {
+	return func(cfg *config) {
+		cfg.DefaultClassificationModel = model
+	}
+}
+
+// WithDefaultVLMModel sets the model used by VLM calls that leave Model empty. Defaults to VLMModelDefault.
+func WithDefaultVLMModel(model string) Option {
+	return func(cfg *config) {
+		cfg.DefaultVLMModel = model
+	}
+}
+
+// WithDefaultDeepSearchModel sets the model used by DeepSearch calls that leave Model empty.
+// Defaults to DeepSearchModelDefault.
+func WithDefaultDeepSearchModel(model string) Option {
+	return func(cfg *config) {
+		cfg.DefaultDeepSearchModel = model
+	}
+}
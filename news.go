@@ -0,0 +1,159 @@
+package jina
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// NewsRequest is a Search tuned for news monitoring. The search engine has no freshness or date sort
+// parameters, so Freshness, SortByDate and Dedup are applied client-side to the returned results.
+type NewsRequest struct {
+	SearchRequest
+
+	// Freshness drops results published longer ago than this, and results without a parsable date.
+	// Zero keeps all results.
+	Freshness time.Duration
+
+	// SortByDate orders results newest first, results without a date last.
+	SortByDate bool
+
+	// Dedup keeps only the first result of each story, comparing normalized URLs and titles,
+	// so syndicated copies of the same article are reported once.
+	Dedup bool
+}
+
+// NewsResult is a search result with its parsed publish date.
+type NewsResult struct {
+	SearchResultData
+
+	// Published is the publish date of the result, zero when the result carries none.
+	Published time.Time
+}
+
+// SearchNews runs a structured Search and returns the results with parsed publish dates,
+// filtered, sorted and deduplicated as configured by req.
+func (cl *Client) SearchNews(ctx context.Context, req NewsRequest, opts ...CallOption) ([]NewsResult, error) {
+	search := req.SearchRequest
+	search.JSONResponse = true
+
+	resp, err := cl.Search(ctx, search, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	now := cl.cfg.Clock.Now()
+	results := make([]NewsResult, 0, len(resp.Structured.Data))
+	seen := map[string]bool{}
+	for _, data := range resp.Structured.Data {
+		result := NewsResult{SearchResultData: data, Published: parsePublishDate(data.Date, now)}
+		if req.Freshness > 0 && (result.Published.IsZero() || now.Sub(result.Published) > req.Freshness) {
+			continue
+		}
+		if req.Dedup {
+			keys := storyKeys(data)
+			if slices.ContainsFunc(keys, func(key string) bool { return seen[key] }) {
+				continue
+			}
+			for _, key := range keys {
+				seen[key] = true
+			}
+		}
+		results = append(results, result)
+	}
+
+	if req.SortByDate {
+		slices.SortStableFunc(results, func(a, b NewsResult) int {
+			if a.Published.IsZero() != b.Published.IsZero() {
+				if a.Published.IsZero() {
+					return 1
+				}
+				return -1
+			}
+			return b.Published.Compare(a.Published)
+		})
+	}
+
+	return results, nil
+}
+
+var (
+	relativeDatePattern = regexp.MustCompile(`^(\d+)\s+(second|minute|min|hour|day|week|month|year)s?\s+ago$`)
+
+	publishDateLayouts = []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		"2006-01-02",
+		time.RFC1123,
+		time.RFC1123Z,
+		"Jan 2, 2006",
+		"January 2, 2006",
+		"2 Jan 2006",
+		"2 January 2006",
+	}
+)
+
+// parsePublishDate parses absolute dates and the relative ones used in search results ("3 hours ago").
+func parsePublishDate(value string, now time.Time) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range publishDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+
+	match := relativeDatePattern.FindStringSubmatch(strings.ToLower(value))
+	if match == nil {
+		return time.Time{}
+	}
+	n, _ := strconv.Atoi(match[1])
+	switch match[2] {
+	case "second":
+		return now.Add(-time.Duration(n) * time.Second)
+	case "minute", "min":
+		return now.Add(-time.Duration(n) * time.Minute)
+	case "hour":
+		return now.Add(-time.Duration(n) * time.Hour)
+	case "day":
+		return now.AddDate(0, 0, -n)
+	case "week":
+		return now.AddDate(0, 0, -7*n)
+	case "month":
+		return now.AddDate(0, -n, 0)
+	default:
+		return now.AddDate(-n, 0, 0)
+	}
+}
+
+// storyKeys returns the keys identifying the story of a result: its URL without query and
+// fragment, and its title without the " - Publisher" suffix, punctuation and case.
+func storyKeys(data SearchResultData) []string {
+	var keys []string
+	if u, err := url.Parse(data.URL); err == nil && u.Host != "" {
+		keys = append(keys, "url:"+strings.TrimPrefix(strings.ToLower(u.Host), "www.")+strings.TrimSuffix(u.Path, "/"))
+	}
+
+	title := data.Title
+	for _, sep := range []string{" - ", " | ", " — "} {
+		if i := strings.LastIndex(title, sep); i > 0 {
+			title = title[:i]
+		}
+	}
+	title = strings.Join(strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}), " ")
+	if title != "" {
+		keys = append(keys, "title:"+title)
+	}
+
+	return keys
+}
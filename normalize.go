@@ -0,0 +1,97 @@
+package jina
+
+import (
+	"fmt"
+	"math"
+)
+
+// NormalizationMode controls the client-side handling of embedding norms, see WithNormalization.
+type NormalizationMode int
+
+const (
+	// NormalizationNone leaves returned embeddings untouched.
+	NormalizationNone NormalizationMode = iota
+
+	// NormalizationVerify fails Embeddings calls returning a vector that is not unit L2 normalized.
+	NormalizationVerify
+
+	// NormalizationApply normalizes every returned vector to unit L2 norm.
+	NormalizationApply
+)
+
+// normalizationEpsilon is the tolerance of NormalizationVerify, float32 rounding leaves norms slightly off 1.
+const normalizationEpsilon = 1e-3
+
+// WithNormalization verifies or applies unit L2 normalization of the embeddings returned by Embeddings.
+// Mixing normalized and unnormalized vectors silently corrupts cosine-similarity and dot-product pipelines.
+func WithNormalization(mode NormalizationMode) Option {
+	return func(cfg *config) {
+		cfg.Normalization = mode
+	}
+}
+
+// NormalizationError is returned by Embeddings with NormalizationVerify when a vector is not normalized.
+type NormalizationError struct {
+	Index int     // Index of the embedding in the response
+	Norm  float64 // L2 norm of the embedding
+}
+
+func (e *NormalizationError) Error() string {
+	return fmt.Sprintf("embedding %d is not normalized: L2 norm %f", e.Index, e.Norm)
+}
+
+// IsNormalized reports whether vec has unit L2 norm within eps.
+func IsNormalized(vec []float32, eps float64) bool {
+	return math.Abs(l2Norm(vec)-1) <= eps
+}
+
+// Normalize scales vec to unit L2 norm in place and returns it. A zero vector is returned unchanged.
+func Normalize(vec []float32) []float32 {
+	norm := l2Norm(vec)
+	if norm == 0 {
+		return vec
+	}
+	for i := range vec {
+		vec[i] = float32(float64(vec[i]) / norm)
+	}
+
+	return vec
+}
+
+func l2Norm(vec []float32) float64 {
+	var sum float64
+	for _, v := range vec {
+		sum += float64(v) * float64(v)
+	}
+
+	return math.Sqrt(sum)
+}
+
+// normalize applies the configured normalization mode to the embeddings of resp.
+func (cl *Client) normalize(resp *EmbeddingsResponse) error {
+	for i := range resp.Data {
+		if err := cl.normalizeEmbedding(&resp.Data[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// normalizeEmbedding applies the configured normalization mode to a single embedding.
+func (cl *Client) normalizeEmbedding(data *EmbeddingData) error {
+	vec := data.Embedding
+	if len(vec) == 0 {
+		return nil
+	}
+	switch cl.cfg.Normalization {
+	case NormalizationVerify:
+		if norm := l2Norm(vec); math.Abs(norm-1) > normalizationEpsilon {
+			return &NormalizationError{Index: data.Index, Norm: norm}
+		}
+	case NormalizationApply:
+		Normalize(vec)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package jina
+
+import (
+	"context"
+	"fmt"
+)
+
+// OpenAIModels maps OpenAI embedding model names to the Jina model used in their place.
+// Names missing here are passed through unchanged, so Jina model names work too.
+var OpenAIModels = map[string]EmbeddingModel{
+	"text-embedding-3-small": EmbeddingModelV3,
+	"text-embedding-3-large": EmbeddingModelV4,
+	"text-embedding-ada-002": EmbeddingModelV3,
+}
+
+// OpenAIEmbedder implements the CreateEmbeddings signature used by OpenAI-compatible Go clients,
+// so code written against OpenAI's embeddings interface can switch to Jina by changing the constructor.
+type OpenAIEmbedder struct {
+	client *Client
+
+	// Task is sent with every request. Defaults to EmbeddingTaskRetrievalPassage.
+	Task EmbeddingTask
+
+	// Models overrides OpenAIModels for this embedder.
+	Models map[string]EmbeddingModel
+}
+
+// NewOpenAIEmbedder returns an OpenAI-compatible embedder backed by a new client.
+func NewOpenAIEmbedder(opts ...Option) *OpenAIEmbedder {
+	return NewClient(opts...).OpenAIEmbedder()
+}
+
+// OpenAIEmbedder returns an OpenAI-compatible embedder backed by the client.
+func (cl *Client) OpenAIEmbedder() *OpenAIEmbedder {
+	return &OpenAIEmbedder{
+		client: cl,
+		Task:   EmbeddingTaskRetrievalPassage,
+	}
+}
+
+// CreateEmbeddings embeds input with the Jina model mapped from model and returns
+// one embedding per input, in input order.
+func (e *OpenAIEmbedder) CreateEmbeddings(ctx context.Context, model string, input []string) ([][]float32, error) {
+	inputs := make([]EmbeddingInput, len(input))
+	for i, text := range input {
+		inputs[i] = NewEmbeddingInputText(text)
+	}
+
+	resp, err := e.client.Embeddings(ctx, EmbeddingsRequest{
+		Model: e.model(model),
+		Input: inputs,
+		Task:  e.Task,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings := make([][]float32, len(input))
+	for _, data := range resp.Data {
+		if data.Index < 0 || data.Index >= len(embeddings) {
+			return nil, fmt.Errorf("embedding index %d out of range", data.Index)
+		}
+		embeddings[data.Index] = data.Embedding
+	}
+
+	return embeddings, nil
+}
+
+func (e *OpenAIEmbedder) model(name string) EmbeddingModel {
+	models := e.Models
+	if models == nil {
+		models = OpenAIModels
+	}
+	if model, ok := models[name]; ok {
+		return model
+	}
+
+	return EmbeddingModel(name)
+}
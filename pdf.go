@@ -0,0 +1,79 @@
+package jina
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PageRange selects the pages First to Last of a PDF, numbered from 1. A zero Last selects up to the end.
+type PageRange struct {
+	First int
+	Last  int
+}
+
+func (r PageRange) contains(page int) bool {
+	return page >= max(r.First, 1) && (r.Last <= 0 || page <= r.Last)
+}
+
+// PDFPage is the content of a single page of a PDF read by the Reader.
+type PDFPage struct {
+	Number  int // Page number, starting at 1
+	Content string
+}
+
+// pdfPageBreak separates the pages of PDF content.
+const pdfPageBreak = "\f"
+
+// Pages returns the content of a PDF response page by page, split at page breaks (form feeds).
+// Content without page breaks is returned as a single page. With ReaderRequest.PDFPages only the
+// selected pages are returned, keeping their original numbers.
+func (r *ReaderResponse) Pages() []PDFPage {
+	if r.pages != nil {
+		return r.pages
+	}
+
+	return splitPDFPages(r.content())
+}
+
+// content returns the page content of a text or structured response.
+func (r *ReaderResponse) content() string {
+	if r.Structured != nil {
+		return r.Structured.Data.Content
+	}
+	return r.Text
+}
+
+func splitPDFPages(content string) []PDFPage {
+	var pages []PDFPage
+	for i, page := range strings.Split(content, pdfPageBreak) {
+		pages = append(pages, PDFPage{Number: i + 1, Content: strings.TrimSpace(page)})
+	}
+
+	return pages
+}
+
+// selectPDFPages keeps the pages of the response within selection, rewriting its content to them.
+func (r *ReaderResponse) selectPDFPages(selection PageRange) error {
+	all := splitPDFPages(r.content())
+	if selection.First > len(all) {
+		return fmt.Errorf("page range starts at page %d, the PDF has %d pages", selection.First, len(all))
+	}
+
+	r.pages = []PDFPage{}
+	contents := make([]string, 0, len(all))
+	for _, page := range all {
+		if selection.contains(page.Number) {
+			r.pages = append(r.pages, page)
+			contents = append(contents, page.Content)
+		}
+	}
+
+	content := strings.Join(contents, "\n"+pdfPageBreak+"\n")
+	if r.Structured != nil {
+		r.Structured.Data.Content = content
+	} else {
+		r.Text = content
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package jina
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KeyStatus is the verdict of the API on the configured key, see Client.ValidateAPIKey.
+type KeyStatus int
+
+const (
+	// KeyStatusUnknown means the check failed before the API judged the key, e.g. on a network error.
+	KeyStatusUnknown KeyStatus = iota
+
+	// KeyStatusValid means the key was accepted.
+	KeyStatusValid
+
+	// KeyStatusMissing means no key is configured.
+	KeyStatusMissing
+
+	// KeyStatusInvalid means the key was rejected, e.g. as it was revoked or mistyped.
+	KeyStatusInvalid
+
+	// KeyStatusInsufficientBalance means the key is valid but has no tokens left.
+	KeyStatusInsufficientBalance
+)
+
+func (s KeyStatus) String() string {
+	switch s {
+	case KeyStatusValid:
+		return "valid"
+	case KeyStatusMissing:
+		return "missing"
+	case KeyStatusInvalid:
+		return "invalid"
+	case KeyStatusInsufficientBalance:
+		return "insufficient balance"
+	default:
+		return "unknown"
+	}
+}
+
+// PingResult is the outcome of Client.Ping.
+type PingResult struct {
+	// KeyValid reports whether the API key was accepted by an authenticated call.
+	KeyValid bool
+
+	// KeyStatus is the verdict on the key, telling invalid keys apart from exhausted ones.
+	KeyStatus KeyStatus
+
+	// KeyError is the reason the key check failed, if it did.
+	KeyError error
+
+	// RateLimit holds the rate-limit headers returned with the authenticated call, if any.
+	RateLimit RateLimit
+
+	// EUCompliance reports whether the client routes traffic through EU infrastructure.
+	EUCompliance bool
+
+	// Endpoints reports the reachability of every endpoint family, including EU variants.
+	Endpoints []EndpointStatus
+}
+
+// OK reports whether the key is valid and every probed endpoint is reachable.
+func (r *PingResult) OK() bool {
+	if !r.KeyValid {
+		return false
+	}
+	for _, status := range r.Endpoints {
+		if !status.Reachable {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EndpointStatus is the reachability of a single endpoint URL.
+type EndpointStatus struct {
+	Endpoint  Endpoint
+	URL       string
+	EU        bool
+	Reachable bool
+	Latency   time.Duration
+	Err       error
+}
+
+// RateLimit holds the rate-limit information sent in X-RateLimit-* response headers.
+// Zero values mean the header was absent.
+type RateLimit struct {
+	Limit     int           // X-RateLimit-Limit: requests allowed in the current window
+	Remaining int           // X-RateLimit-Remaining: requests left in the current window
+	Reset     time.Duration // X-RateLimit-Reset: time until the window resets
+}
+
+// parseRateLimit parses the X-RateLimit-* headers, with reset timestamps relative to now.
+func parseRateLimit(header http.Header, now time.Time) RateLimit {
+	var rl RateLimit
+	rl.Limit, _ = strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	rl.Remaining, _ = strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		// Some gateways send seconds until reset, others a unix timestamp
+		if reset > 1_000_000_000 {
+			rl.Reset = time.Unix(reset, 0).Sub(now)
+		} else {
+			rl.Reset = time.Duration(reset) * time.Second
+		}
+	}
+
+	return rl
+}
+
+// Ping validates the API key with a minimal embeddings call (costing a handful of tokens)
+// and probes the reachability of every endpoint family, including the EU variants.
+// Problems are reported on the result rather than as an error; the error is only
+// non-nil when ctx is done.
+func (cl *Client) Ping(ctx context.Context) (*PingResult, error) {
+	ctx, done, err := cl.drain.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PingResult{
+		EUCompliance: cl.cfg.EUCompliance,
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cl.pingKey(ctx, result)
+	}()
+
+	urls, euURLs := cl.endpointURLs()
+	probes := make([]EndpointStatus, 0, len(Endpoints)+len(euURLs))
+	for _, endpoint := range Endpoints {
+		if url, ok := urls[endpoint]; ok {
+			probes = append(probes, EndpointStatus{Endpoint: endpoint, URL: url})
+		}
+		if euURL, ok := euURLs[endpoint]; ok {
+			probes = append(probes, EndpointStatus{Endpoint: endpoint, URL: euURL, EU: true})
+		}
+	}
+	for i := range probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cl.probe(ctx, &probes[i])
+		}()
+	}
+	wg.Wait()
+
+	result.Endpoints = probes
+	return result, done(ctx.Err())
+}
+
+// ValidateAPIKey checks the API key with a minimal embeddings call (costing a handful of tokens), so
+// services can fail fast at startup. The error is nil only for KeyStatusValid, it is the *APIError
+// of rejected keys.
+func (cl *Client) ValidateAPIKey(ctx context.Context) (KeyStatus, error) {
+	ctx, done, err := cl.drain.begin(ctx)
+	if err != nil {
+		return KeyStatusUnknown, err
+	}
+
+	var result PingResult
+	cl.pingKey(ctx, &result)
+
+	return result.KeyStatus, done(result.KeyError)
+}
+
+func (cl *Client) pingKey(ctx context.Context, result *PingResult) {
+	if cl.cfg.APIKey == "" && cl.keys == nil && cl.cfg.APIKeyFunc == nil {
+		result.KeyStatus = KeyStatusMissing
+		result.KeyError = errors.New("no API key configured")
+		return
+	}
+
+	call := newCallConfig(nil)
+	url, err := cl.resolveEndpointURL(EndpointEmbeddings, call.euCompliance(cl.cfg))
+	if err != nil {
+		result.KeyError = err
+		return
+	}
+
+	reqBody, err := newRequestBody(EmbeddingsRequest{
+		Model: EmbeddingModelV3,
+		Input: []EmbeddingInput{NewEmbeddingInputText("ping")},
+	})
+	if err != nil {
+		result.KeyError = fmt.Errorf("failed to marshal request: %w", err)
+		return
+	}
+	defer reqBody.release()
+
+	httpReq, err := cl.newRequest(ctx, url, reqBody)
+	if err != nil {
+		result.KeyError = err
+		return
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if httpReq, err = cl.setCallHeaders(httpReq, call); err != nil {
+		result.KeyError = err
+		return
+	}
+
+	resp, err := cl.do(httpReq)
+	if err != nil {
+		result.KeyError = fmt.Errorf("do request: %w", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	result.RateLimit = parseRateLimit(resp.Header, cl.cfg.Clock.Now())
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		apiErr := cl.newAPIError(httpReq, resp, body)
+		result.KeyStatus = apiErr.keyStatus()
+		result.KeyError = apiErr
+		return
+	}
+	result.KeyValid = true
+	result.KeyStatus = KeyStatusValid
+}
+
+// keyStatus classifies the error response to an authenticated call.
+func (e *APIError) keyStatus() KeyStatus {
+	switch {
+	case e.StatusCode == http.StatusPaymentRequired || strings.Contains(strings.ToLower(e.Name+e.Detail), "balance"):
+		return KeyStatusInsufficientBalance
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return KeyStatusInvalid
+	default:
+		return KeyStatusUnknown
+	}
+}
+
+// probe reports an endpoint as reachable when it answers with any HTTP response.
+func (cl *Client) probe(ctx context.Context, status *EndpointStatus) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, status.URL, nil)
+	if err != nil {
+		status.Err = err
+		return
+	}
+	cl.setStaticHeaders(httpReq)
+
+	start := cl.cfg.Clock.Now()
+	resp, err := cl.do(httpReq)
+	status.Latency = cl.cfg.Clock.Now().Sub(start)
+	if err != nil {
+		status.Err = err
+		return
+	}
+	resp.Body.Close()
+	status.Reachable = true
+}
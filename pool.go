@@ -0,0 +1,102 @@
+package jina
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// maxPooledBufferSize stops a single huge response from pinning its buffer in the pool.
+const maxPooledBufferSize = 4 << 20
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// lineBufferSize is the initial size of the pooled buffers streams are scanned with. Scanners grow
+// past it for longer lines, up to maxPooledBufferSize, without returning the grown buffer.
+const lineBufferSize = 64 << 10
+
+var lineBufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, lineBufferSize)
+		return &buf
+	},
+}
+
+// newLineScanner returns a scanner over the lines of r reading into a pooled buffer, and the func
+// returning the buffer once the scanner and the lines it returned are no longer used.
+func newLineScanner(r io.Reader) (*bufio.Scanner, func()) {
+	buf := lineBufferPool.Get().(*[]byte)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(*buf, maxPooledBufferSize)
+
+	return scanner, func() { lineBufferPool.Put(buf) }
+}
+
+// requestBody is a JSON request body encoded into a pooled buffer.
+// The transport may read the body after Do returns, so the buffer only goes back to the pool
+// once every reader handed out has been closed and the owner has called release.
+type requestBody struct {
+	buf  *bytes.Buffer
+	refs atomic.Int32
+}
+
+func newRequestBody(v any) (*requestBody, error) {
+	buf := getBuffer()
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		putBuffer(buf)
+		return nil, err
+	}
+
+	body := &requestBody{buf: buf}
+	body.refs.Store(1)
+	return body, nil
+}
+
+func (b *requestBody) len() int {
+	return b.buf.Len()
+}
+
+// reader returns a new reader over the body, suitable for http.Request.Body and GetBody.
+func (b *requestBody) reader() io.ReadCloser {
+	b.refs.Add(1)
+	return &requestBodyReader{Reader: bytes.NewReader(b.buf.Bytes()), body: b}
+}
+
+// putRequestBuffer returns the buffer of a released request body, tests count the calls.
+var putRequestBuffer = putBuffer
+
+func (b *requestBody) release() {
+	if b.refs.Add(-1) == 0 {
+		putRequestBuffer(b.buf)
+	}
+}
+
+type requestBodyReader struct {
+	*bytes.Reader
+	body *requestBody
+	once sync.Once
+}
+
+func (r *requestBodyReader) Close() error {
+	r.once.Do(r.body.release)
+	return nil
+}
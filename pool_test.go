@@ -0,0 +1,157 @@
+package jina
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// benchmarkClient returns a client sending calls to endpoint to a local server answering with body.
+func benchmarkClient(b *testing.B, endpoint Endpoint, body string) *Client {
+	b.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	b.Cleanup(srv.Close)
+
+	return NewClient(
+		WithAPIKey("jina_benchmark-api-key"),
+		WithHTTPClient(srv.Client()),
+		WithBaseURLs(map[Endpoint]string{endpoint: srv.URL}),
+	)
+}
+
+func benchmarkEmbeddingsRequest(n int) EmbeddingsRequest {
+	req := EmbeddingsRequest{Model: EmbeddingModelV3, Input: make([]EmbeddingInput, n)}
+	for i := range req.Input {
+		req.Input[i] = EmbeddingInput{Text: fmt.Sprintf("document %d about %s", i, strings.Repeat("search ", 20))}
+	}
+
+	return req
+}
+
+func TestRequestBodyRetriesAndRedirects(t *testing.T) {
+	var mu sync.Mutex
+	var released [][]byte
+	putRequestBuffer = func(buf *bytes.Buffer) {
+		mu.Lock()
+		released = append(released, bytes.Clone(buf.Bytes()))
+		mu.Unlock()
+		putBuffer(buf)
+	}
+	t.Cleanup(func() { putRequestBuffer = putBuffer })
+
+	var bodies [][]byte
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading request body: %v", err)
+		}
+		mu.Lock()
+		bodies = append(bodies, body)
+		attempt := len(bodies)
+		mu.Unlock()
+
+		switch {
+		case attempt == 1:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"detail":"failed"}`))
+		case r.URL.Path == "/embeddings":
+			http.Redirect(w, r, "/embeddings/moved", http.StatusTemporaryRedirect)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"model":"jina-embeddings-v3","usage":{"total_tokens":1},"data":[{"object":"embedding","index":0,"embedding":[0.5]}]}`))
+		}
+	}, WithClock(newFakeClock()), WithRetryPolicy(RetryClassIdempotent, RetryPolicy{MaxAttempts: 3, Backoff: time.Second}))
+
+	if _, err := cl.Embeddings(context.Background(), benchmarkEmbeddingsRequest(4)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The transport may close the last body after the call returned.
+	waitUntil(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(released) > 0
+	})
+	mu.Lock()
+	defer mu.Unlock()
+	if len(bodies) != 3 {
+		t.Fatalf("server got %d requests, want a failed attempt, a redirect and its target", len(bodies))
+	}
+	if len(released) != 1 {
+		t.Fatalf("request buffer returned to the pool %d times, want once", len(released))
+	}
+	for i, body := range bodies {
+		if len(body) == 0 || !bytes.Equal(body, released[0]) {
+			t.Errorf("request %d body = %q, want %q", i+1, body, released[0])
+		}
+	}
+}
+
+func BenchmarkNewRequestBody(b *testing.B) {
+	req := benchmarkEmbeddingsRequest(128)
+	b.ReportAllocs()
+	for b.Loop() {
+		body, err := newRequestBody(req)
+		if err != nil {
+			b.Fatal(err)
+		}
+		body.release()
+	}
+}
+
+func BenchmarkEmbeddings(b *testing.B) {
+	var resp strings.Builder
+	resp.WriteString(`{"model":"jina-embeddings-v3","usage":{"total_tokens":4096},"data":[`)
+	for i := range 32 {
+		if i > 0 {
+			resp.WriteByte(',')
+		}
+		fmt.Fprintf(&resp, `{"object":"embedding","index":%d,"embedding":[%s0.5]}`, i, strings.Repeat("0.125,", 1023))
+	}
+	resp.WriteString(`]}`)
+	cl := benchmarkClient(b, EndpointEmbeddings, resp.String())
+	req := benchmarkEmbeddingsRequest(32)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := cl.Embeddings(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRerank(b *testing.B) {
+	var resp strings.Builder
+	resp.WriteString(`{"model":"jina-reranker-v2-base-multilingual","usage":{"total_tokens":2048},"results":[`)
+	for i := range 64 {
+		if i > 0 {
+			resp.WriteByte(',')
+		}
+		fmt.Fprintf(&resp, `{"index":%d,"relevance_score":0.%d}`, i, 99-i)
+	}
+	resp.WriteString(`]}`)
+	cl := benchmarkClient(b, EndpointRerank, resp.String())
+	req := RerankRequest{Model: RerankerModelV2BaseMultilingual, Query: "what is vector search?", Documents: make([]string, 64)}
+	for i := range req.Documents {
+		req.Documents[i] = fmt.Sprintf("document %d about %s", i, strings.Repeat("search ", 20))
+	}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := cl.Rerank(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
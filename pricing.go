@@ -0,0 +1,75 @@
+package jina
+
+// Price is the cost of a model in USD per million tokens.
+type Price struct {
+	// Input is the price of prompt tokens, and of all tokens of usage reported without a breakdown.
+	Input float64 `json:"input"`
+
+	// Output is the price of completion tokens.
+	Output float64 `json:"output"`
+}
+
+// Pricing maps models to their price. Usage reported without a model, e.g. by search, is looked up
+// by its endpoint instead.
+type Pricing map[string]Price
+
+// jinaTokenPrice is the flat per-token price of the paid Jina API plans, which bill every model alike.
+var jinaTokenPrice = Price{Input: 0.05, Output: 0.05}
+
+// DefaultPricing holds the list prices of the models served by the Jina API when this table was
+// last updated. Prices change and plans differ, so budgets that matter should set their own with
+// WithPricing. The segmenter is not billed and has no entry.
+var DefaultPricing = Pricing{
+	string(EmbeddingModelV4):                jinaTokenPrice,
+	string(EmbeddingModelV3):                jinaTokenPrice,
+	string(EmbeddingModelClipV2):            jinaTokenPrice,
+	string(EmbeddingModelCode0_5B):          jinaTokenPrice,
+	string(EmbeddingModelCode1_5B):          jinaTokenPrice,
+	string(RerankerModelV3):                 jinaTokenPrice,
+	string(RerankerModelM0):                 jinaTokenPrice,
+	string(RerankerModelV2BaseMultilingual): jinaTokenPrice,
+	string(RerankerModelColbertV2):          jinaTokenPrice,
+	DeepSearchModelDefault:                  jinaTokenPrice,
+	VLMModelDefault:                         jinaTokenPrice,
+	string(EndpointClassify):                jinaTokenPrice, // Calls to trained classifiers
+	string(EndpointTrain):                   jinaTokenPrice,
+	string(EndpointReader):                  jinaTokenPrice,
+	string(EndpointSearch):                  jinaTokenPrice,
+}
+
+// WithPricing replaces the prices used to estimate the cost of the usage recorded by Client.Usage.
+// Defaults to DefaultPricing.
+func WithPricing(pricing Pricing) Option {
+	return func(cfg *config) {
+		cfg.Pricing = pricing
+	}
+}
+
+// EstimateCost estimates the cost in USD of usage of model with DefaultPricing,
+// reporting false for models without a price.
+func EstimateCost(usage Usage, model string) (float64, bool) {
+	return DefaultPricing.EstimateCost(usage, model)
+}
+
+// EstimateCost estimates the cost in USD of usage of model, reporting false for models without a price.
+func (p Pricing) EstimateCost(usage Usage, model string) (float64, bool) {
+	price, ok := p[model]
+	if !ok {
+		return 0, false
+	}
+	if usage.PromptTokens == 0 && usage.CompletionTokens == 0 {
+		return float64(usage.TotalTokens) * price.Input / 1e6, true
+	}
+
+	return (float64(usage.PromptTokens)*price.Input + float64(usage.CompletionTokens)*price.Output) / 1e6, true
+}
+
+// cost estimates the cost of a call by its model, falling back to its endpoint.
+func (p Pricing) cost(endpoint Endpoint, model string, usage Usage) float64 {
+	if cost, ok := p.EstimateCost(usage, model); ok {
+		return cost
+	}
+	cost, _ := p.EstimateCost(usage, string(endpoint))
+
+	return cost
+}
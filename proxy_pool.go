@@ -0,0 +1,193 @@
+package jina
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProxySelection is the strategy used by a ProxyPool to pick the next proxy.
+type ProxySelection int
+
+const (
+	// ProxySelectionRoundRobin cycles through the proxies in order.
+	ProxySelectionRoundRobin ProxySelection = iota
+
+	// ProxySelectionRandom picks a random proxy for every call.
+	ProxySelectionRandom
+)
+
+// ProxyPool rotates the egress proxies used by Reader, benching proxies that get blocked.
+// It is safe for concurrent use, see WithProxyPool.
+type ProxyPool struct {
+	selection ProxySelection
+	bench     time.Duration
+	blocked   func(resp *ReaderResponse, err error) bool
+	clock     Clock
+
+	mu      sync.Mutex
+	proxies []*pooledProxy
+	next    int
+}
+
+type pooledProxy struct {
+	url          string
+	benchedUntil time.Time
+	stats        ProxyStats
+}
+
+// ProxyStats is the usage tracked for one proxy of a ProxyPool.
+type ProxyStats struct {
+	URL          string
+	Requests     int       // Reader calls made through the proxy
+	Blocked      int       // Calls detected as blocked
+	BenchedUntil time.Time // Zero or in the past when the proxy is available
+}
+
+// ProxyPoolOption configures a ProxyPool.
+type ProxyPoolOption func(*ProxyPool)
+
+// WithProxySelection sets the rotation strategy. Defaults to ProxySelectionRoundRobin.
+func WithProxySelection(selection ProxySelection) ProxyPoolOption {
+	return func(p *ProxyPool) {
+		p.selection = selection
+	}
+}
+
+// WithProxyBench sets how long a blocked proxy is skipped. Defaults to 5 minutes.
+func WithProxyBench(d time.Duration) ProxyPoolOption {
+	return func(p *ProxyPool) {
+		p.bench = d
+	}
+}
+
+// WithProxyBlockDetector replaces the check deciding whether a Reader call was blocked at the proxy.
+// The default treats API errors with status 403, 407, 429 and 451, and responses whose warning reports
+// one of these statuses from the target, as blocks.
+func WithProxyBlockDetector(blocked func(resp *ReaderResponse, err error) bool) ProxyPoolOption {
+	return func(p *ProxyPool) {
+		p.blocked = blocked
+	}
+}
+
+// WithProxyClock replaces the wall clock used to bench blocked proxies. Defaults to SystemClock.
+func WithProxyClock(clock Clock) ProxyPoolOption {
+	return func(p *ProxyPool) {
+		p.clock = clock
+	}
+}
+
+// NewProxyPool returns a pool rotating through the given proxy URLs.
+func NewProxyPool(proxyURLs []string, opts ...ProxyPoolOption) *ProxyPool {
+	p := &ProxyPool{
+		bench:   5 * time.Minute,
+		blocked: proxyBlocked,
+		clock:   SystemClock,
+	}
+	for _, proxyURL := range proxyURLs {
+		p.proxies = append(p.proxies, &pooledProxy{url: proxyURL, stats: ProxyStats{URL: proxyURL}})
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// WithProxyPool makes Reader send the call through the next proxy of pool, unless the request sets
+// ProxyURL itself. Calls detected as blocked bench their proxy for a while.
+func WithProxyPool(pool *ProxyPool) CallOption {
+	return func(call *callConfig) {
+		call.proxies = pool
+	}
+}
+
+// Stats reports the tracked usage of every proxy, in order.
+func (p *ProxyPool) Stats() []ProxyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make([]ProxyStats, len(p.proxies))
+	for i, proxy := range p.proxies {
+		stats[i] = proxy.stats
+		stats[i].BenchedUntil = proxy.benchedUntil
+	}
+
+	return stats
+}
+
+// pick returns the proxy for the next call. When every proxy is benched, the one released first is used.
+func (p *ProxyPool) pick() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.proxies) == 0 {
+		return ""
+	}
+
+	start := p.next
+	if p.selection == ProxySelectionRandom {
+		start = rand.IntN(len(p.proxies))
+	}
+	p.next = (start + 1) % len(p.proxies)
+
+	now := p.clock.Now()
+	var best *pooledProxy
+	for i := range p.proxies {
+		proxy := p.proxies[(start+i)%len(p.proxies)]
+		if !now.Before(proxy.benchedUntil) {
+			return proxy.url
+		}
+		if best == nil || proxy.benchedUntil.Before(best.benchedUntil) {
+			best = proxy
+		}
+	}
+
+	return best.url
+}
+
+// observe records the outcome of a Reader call made through proxyURL.
+func (p *ProxyPool) observe(proxyURL string, resp *ReaderResponse, err error) {
+	blocked := p.blocked(resp, err)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, proxy := range p.proxies {
+		if proxy.url != proxyURL {
+			continue
+		}
+		proxy.stats.Requests++
+		if blocked {
+			proxy.stats.Blocked++
+			proxy.benchedUntil = p.clock.Now().Add(p.bench)
+		}
+		return
+	}
+}
+
+var blockStatuses = []int{http.StatusForbidden, http.StatusProxyAuthRequired, http.StatusTooManyRequests, http.StatusUnavailableForLegalReasons}
+
+func proxyBlocked(resp *ReaderResponse, err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return slices.Contains(blockStatuses, apiErr.StatusCode)
+	}
+	if resp == nil || resp.Structured == nil {
+		return false
+	}
+
+	warning := resp.Structured.Data.Warning
+	for _, status := range blockStatuses {
+		if strings.Contains(warning, http.StatusText(status)) || strings.Contains(warning, " "+strconv.Itoa(status)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,129 @@
+package jina
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimiter throttles the calls to an endpoint before they are sent. Wait blocks until a call may
+// proceed, returning an error when ctx is done first. *rate.Limiter of golang.org/x/time/rate
+// satisfies it. See WithRateLimiter.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// ErrRateLimited is returned when a RateLimiter fails a call instead of waiting for it, e.g. a
+// *rate.Limiter whose wait would exceed the context deadline. Such calls are not retried.
+var ErrRateLimited = errors.New("client-side rate limit")
+
+// WithRateLimiter throttles calls to the endpoint with limiter, e.g. a *rate.Limiter shared by
+// several clients using the same API key. Every attempt waits, so retries count against the limit.
+// It replaces the token bucket of the endpoint set by WithRateLimits.
+func WithRateLimiter(endpoint Endpoint, limiter RateLimiter) Option {
+	return func(cfg *config) {
+		if cfg.RateLimiters == nil {
+			cfg.RateLimiters = make(map[Endpoint]RateLimiter)
+		}
+		cfg.RateLimiters[endpoint] = limiter
+	}
+}
+
+// WithRateLimits throttles concurrent callers to the requests per minute allowed for each endpoint
+// by the API key's plan, so calls queue client-side instead of failing with a 429:
+//
+//	jina.WithRateLimits(map[jina.Endpoint]int{
+//		jina.EndpointEmbeddings: 500,
+//		jina.EndpointReader:     200,
+//	})
+//
+// Each endpoint gets a token bucket refilled at the given rate, holding a second's worth of calls so
+// short bursts go out at once. Endpoints missing from the map are not throttled.
+func WithRateLimits(requestsPerMinute map[Endpoint]int) Option {
+	return func(cfg *config) {
+		if cfg.RateLimits == nil {
+			cfg.RateLimits = make(map[Endpoint]int)
+		}
+		for endpoint, rpm := range requestsPerMinute {
+			cfg.RateLimits[endpoint] = rpm
+		}
+	}
+}
+
+// rateLimiters returns the limiter of every throttled endpoint.
+func (cfg *config) rateLimiters() map[Endpoint]RateLimiter {
+	limiters := make(map[Endpoint]RateLimiter, len(cfg.RateLimits)+len(cfg.RateLimiters))
+	for endpoint, rpm := range cfg.RateLimits {
+		if rpm > 0 {
+			limiters[endpoint] = newTokenBucket(rpm, cfg.Clock)
+		}
+	}
+	for endpoint, limiter := range cfg.RateLimiters {
+		limiters[endpoint] = limiter
+	}
+
+	return limiters
+}
+
+// waitRateLimit blocks until the endpoint's limiter lets a call through.
+func (cl *Client) waitRateLimit(ctx context.Context, endpoint Endpoint) error {
+	limiter, ok := cl.limiters[endpoint]
+	if !ok {
+		return nil
+	}
+
+	if err := limiter.Wait(ctx); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("%s: %w: %w", endpoint, ErrRateLimited, err)
+	}
+
+	return nil
+}
+
+// tokenBucket is a RateLimiter tracking when the bucket would next be full, rather than its tokens.
+type tokenBucket struct {
+	clock    Clock
+	interval time.Duration // Time to refill one token
+	burst    int
+
+	mu   sync.Mutex
+	full time.Time
+}
+
+func newTokenBucket(requestsPerMinute int, clock Clock) *tokenBucket {
+	return &tokenBucket{
+		clock:    clock,
+		interval: time.Minute / time.Duration(requestsPerMinute),
+		burst:    max(requestsPerMinute/60, 1),
+	}
+}
+
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := b.clock.Now()
+	if b.full.Before(now) {
+		b.full = now
+	}
+	// Taking a token pushes the time the bucket is full again out by one interval, the call has to
+	// wait while that is more than a full bucket away
+	b.full = b.full.Add(b.interval)
+	wait := b.full.Sub(now) - time.Duration(b.burst)*b.interval
+	b.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+
+	if err := b.clock.Sleep(ctx, wait); err != nil {
+		// Return the token, the call was never made
+		b.mu.Lock()
+		b.full = b.full.Add(-b.interval)
+		b.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
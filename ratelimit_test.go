@@ -0,0 +1,42 @@
+package jina
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	clock := newFakeClock()
+	// 120 requests per minute refill a token every 500ms, with a burst of 2
+	bucket := newTokenBucket(120, clock)
+	ctx := context.Background()
+
+	for range 4 {
+		if err := bucket.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := []time.Duration{500 * time.Millisecond, 500 * time.Millisecond}; !slices.Equal(clock.Sleeps(), want) {
+		t.Fatalf("slept %v, want %v", clock.Sleeps(), want)
+	}
+
+	// An idle bucket fills up to its burst, not beyond
+	clock.Advance(time.Minute)
+	for range 3 {
+		if err := bucket.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := []time.Duration{500 * time.Millisecond, 500 * time.Millisecond, 500 * time.Millisecond}; !slices.Equal(clock.Sleeps(), want) {
+		t.Errorf("slept %v, want %v", clock.Sleeps(), want)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := bucket.Wait(canceled); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait with a cancelled context = %v, want context.Canceled", err)
+	}
+}
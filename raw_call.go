@@ -0,0 +1,117 @@
+package jina
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// EndpointCustom identifies calls made with Client.Call, e.g. to set their timeout with
+// WithEndpointTimeout. They are retried with the policy of RetryClassIdempotent.
+const EndpointCustom Endpoint = "custom"
+
+// Call sends a request to an endpoint this package does not wrap yet, e.g. a beta API, with the
+// client's authentication, headers, retries, rate limits and error handling:
+//
+//	var out struct {
+//		Data []struct{ Score float64 } `json:"data"`
+//	}
+//	err := client.Call(ctx, http.MethodPost, "/v1/new-endpoint", nil, map[string]any{"input": "hi"}, &out)
+//
+// path is resolved against the URL of EndpointCustom in the configured deployment, https://api.jina.ai
+// or https://eu.api.jina.ai with EU compliance for the public API, unless it is an absolute URL, such as
+// one on r.jina.ai. Override the base with WithEndpointURL(EndpointCustom, ...). With EU compliance,
+// absolute URLs must point at a host of the client's EU endpoints. header overrides the client-wide
+// headers like WithCallHeader. body is marshalled to JSON, pass a json.RawMessage to send it verbatim,
+// or nil to send none. The response is decoded into out as JSON, or copied verbatim when out is a
+// *[]byte, and discarded when out is nil. Non-200 responses are returned as an *APIError.
+func (cl *Client) Call(ctx context.Context, method, path string, header http.Header, body, out any, opts ...CallOption) error {
+	call := newCallConfig(opts)
+	requestURL, err := cl.callURL(path, call)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, requestURL, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		reqBody, err := newRequestBody(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		defer reqBody.release()
+		if err := cl.cfg.Limits.checkRequestBody(reqBody); err != nil {
+			return err
+		}
+		if err := cl.compressRequestBody(httpReq, reqBody); err != nil {
+			return err
+		}
+
+		httpReq.Body = reqBody.reader()
+		httpReq.ContentLength = int64(reqBody.len())
+		httpReq.GetBody = func() (io.ReadCloser, error) {
+			return reqBody.reader(), nil
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if len(header) > 0 && call.header == nil {
+		call.header = make(http.Header, len(header))
+	}
+	for key, values := range header {
+		call.header[http.CanonicalHeaderKey(key)] = values
+	}
+	if httpReq, err = cl.setCallHeaders(httpReq, call); err != nil {
+		return err
+	}
+
+	return cl.send(EndpointCustom, httpReq, call, func(respBody []byte) error {
+		switch out := out.(type) {
+		case nil:
+		case *[]byte:
+			*out = bytes.Clone(respBody)
+		default:
+			if err := json.Unmarshal(respBody, out); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// callURL resolves the path of a Call against the client's EndpointCustom URL, and checks that
+// absolute URLs stay on EU hosts with EU compliance.
+func (cl *Client) callURL(path string, call *callConfig) (string, error) {
+	eu := call.euCompliance(cl.cfg)
+	if u, err := url.Parse(path); err == nil && u.IsAbs() {
+		if eu && !cl.isEUHost(u.Host) {
+			return "", fmt.Errorf("%s is not an EU host: %w", u.Host, &NoEUEndpointError{Endpoint: EndpointCustom})
+		}
+		return path, nil
+	}
+	base, err := cl.resolveEndpointURL(EndpointCustom, eu)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(path, "/"), nil
+}
+
+// isEUHost reports whether host serves one of the client's EU endpoints.
+func (cl *Client) isEUHost(host string) bool {
+	_, euURLs := cl.endpointURLs()
+	for _, endpointURL := range euURLs {
+		if u, err := url.Parse(endpointURL); err == nil && strings.EqualFold(u.Host, host) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,13 +1,13 @@
 package jina
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 type BrowserEngine string
@@ -42,7 +42,14 @@ const (
 
 type ReaderRequest struct {
 	// URL is the URL to read and extract content from.
-	URL string `json:"url"`
+	URL string `json:"url,omitempty"`
+
+	// PDF is a base64 encoded PDF to read instead of fetching URL.
+	PDF string `json:"pdf,omitempty"`
+
+	// PDFPages keeps only the selected pages of a PDF, see ReaderResponse.Pages.
+	// The whole PDF is still read and billed, the selection is applied to the returned content.
+	PDFPages *PageRange `json:"-"`
 
 	// Viewport sets browser viewport dimensions for responsive rendering.
 	Viewport *Viewport `json:"viewport,omitempty"`
@@ -61,6 +68,10 @@ type ReaderRequest struct {
 	// ContentFormat specifies the return format: markdown, html, text, screenshot, or pageshot (for URL of full-page screenshot).
 	ContentFormat ContentFormat `json:"-"`
 
+	// ParseMetadata parses the OpenGraph tags and JSON-LD blocks of the page into ReaderResponse.Metadata.
+	// Requires ContentFormatHTML.
+	ParseMetadata bool `json:"-"`
+
 	// Timeout specifies the maximum time (in seconds) to wait for the webpage to load.
 	Timeout int `json:"-"`
 
@@ -100,7 +111,7 @@ type ReaderRequest struct {
 	// SetCookie forwards your custom cookie settings when accessing the URL, which is useful for pages requiring extra authentication. Note that requests with cookies will not be cached.
 	SetCookie string `json:"-"`
 
-	// ProxyURL utilizes your proxy to access URLs, which is helpful for pages accessible only through specific proxies.
+	// ProxyURL utilizes your proxy to access URLs, which is helpful for pages accessible only through specific proxies. See WithProxyPool to rotate several proxies.
 	ProxyURL string `json:"-"`
 
 	// ProxyCountry sets country code for location-based proxy server. Use 'auto' for optimal selection or 'none' to disable.
@@ -115,6 +126,9 @@ type ReaderRequest struct {
 	// BrowserLocale controls the browser locale to render the page. Lots of websites serve different content based on the locale.
 	BrowserLocale string `json:"-"`
 
+	// UserAgent overrides the User-Agent of the browser rendering the page.
+	UserAgent string `json:"-"`
+
 	// RobotsTxt defines bot User-Agent to check against robots.txt before fetching content. Websites may allow different behaviors based on the User-Agent.
 	RobotsTxt string `json:"-"`
 
@@ -152,11 +166,35 @@ type ReaderRequest struct {
 type Viewport struct {
 	Width  int `json:"width"`
 	Height int `json:"height"`
+
+	// DeviceScaleFactor is the device pixel ratio. Default: 1.
+	DeviceScaleFactor float64 `json:"deviceScaleFactor,omitempty"`
+
+	// IsMobile enables the mobile meta viewport, HasTouch touch events.
+	IsMobile bool `json:"isMobile,omitempty"`
+	HasTouch bool `json:"hasTouch,omitempty"`
 }
 
 type ReaderResponse struct {
 	Text       string                    // Raw text response (when JSON is not requested)
 	Structured *StructuredReaderResponse // Structured JSON response
+
+	// Metadata holds the page's OpenGraph and JSON-LD metadata when ParseMetadata was requested.
+	Metadata *PageMetadata
+
+	// Meta describes the HTTP exchange the response was received in.
+	Meta Meta
+
+	imageCaption bool      // ImageCaption was requested, see Images
+	pages        []PDFPage // Selected with PDFPages, see Pages
+}
+
+// usage returns the tokens reported by a structured response. Text responses carry no usage.
+func (r *ReaderResponse) usage() Usage {
+	if r.Structured == nil {
+		return Usage{}
+	}
+	return Usage{TotalTokens: r.Structured.Data.Usage.Tokens}
 }
 
 type StructuredReaderResponse struct {
@@ -184,193 +222,231 @@ type StructuredReaderResponse struct {
 }
 
 // Reader calls the Jina Reader API to retrieve and parse content from a URL.
-func (cl *Client) Reader(ctx context.Context, req ReaderRequest) (*ReaderResponse, error) {
-	if req.URL == "" {
-		return nil, fmt.Errorf("URL is required")
+func (cl *Client) Reader(ctx context.Context, req ReaderRequest, opts ...CallOption) (*ReaderResponse, error) {
+	if req.URL == "" && req.PDF == "" {
+		return nil, fmt.Errorf("URL or PDF is required")
 	}
-	if cl.cfg.EUCompliance {
+	call := newCallConfig(opts)
+	if call.euCompliance(cl.cfg) {
 		req.EUCompliance = true
 	}
+	if call.robots != nil && req.URL != "" {
+		allowed, err := call.robots.Allowed(ctx, req.URL)
+		if err != nil {
+			return nil, fmt.Errorf("check robots.txt: %w", err)
+		}
+		if !allowed {
+			return nil, &RobotsDisallowedError{URL: req.URL, UserAgent: call.robots.userAgent}
+		}
+	}
 
-	requestURL := cl.buildReaderURL(req)
-
-	// Marshal only the body parameters
-	jsonData, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	var proxyURL string
+	if call.proxies != nil && req.ProxyURL == "" {
+		proxyURL = call.proxies.pick()
+		req.ProxyURL = proxyURL
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewBuffer(jsonData))
+	resp, err := cl.read(ctx, req, call)
+	if proxyURL != "" {
+		call.proxies.observe(proxyURL, resp, err)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, err
 	}
+	cl.reportUsage(ctx, EndpointReader, req.RespondWith, resp.usage())
 
-	httpReq.Header.Set("Content-Type", "application/json")
-	cl.setReaderHeaders(httpReq, req)
-
-	client := &http.Client{}
+	return resp, nil
+}
 
-	resp, err := client.Do(httpReq)
+func (cl *Client) read(ctx context.Context, req ReaderRequest, call *callConfig) (*ReaderResponse, error) {
+	requestURL, err := cl.resolveEndpointURL(EndpointReader, req.EUCompliance)
 	if err != nil {
-		return nil, fmt.Errorf("do request: %w", err)
+		return nil, err
 	}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(body))
+	// Marshal only the body parameters
+	reqBody, err := newRequestBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	defer reqBody.release()
+	if err := cl.cfg.Limits.checkRequestBody(reqBody); err != nil {
+		return nil, err
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	httpReq, err := cl.newRequest(ctx, requestURL, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("read response body: %w", err)
+		return nil, err
+	}
+	cl.setReaderHeaders(httpReq, req)
+	if httpReq, err = cl.setCallHeaders(httpReq, call); err != nil {
+		return nil, err
 	}
 
-	return cl.parseReaderResponse(body, req.JSONResponse)
-}
-
-func (cl *Client) buildReaderURL(args ReaderRequest) string {
-	baseURL := "https://r.jina.ai/"
-	if args.EUCompliance {
-		baseURL = "https://eu.r.jina.ai/"
+	var resp *ReaderResponse
+	err = cl.sendStreaming(EndpointReader, httpReq, call, func(body io.Reader) error {
+		if resp, err = cl.parseReaderResponse(body, req.JSONResponse); err != nil {
+			return err
+		}
+		call.usage = resp.usage()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Meta = call.meta
+	resp.imageCaption = req.ImageCaption
+	if req.PDFPages != nil {
+		if err := resp.selectPDFPages(*req.PDFPages); err != nil {
+			return nil, err
+		}
+	}
+	if req.ParseMetadata && req.ContentFormat == ContentFormatHTML {
+		document := resp.Text
+		if resp.Structured != nil {
+			document = resp.Structured.Data.Content
+		}
+		resp.Metadata = ParsePageMetadata(document)
 	}
 
-	return baseURL
+	return resp, nil
 }
 
 func (cl *Client) setReaderHeaders(httpReq *http.Request, req ReaderRequest) {
-	httpReq.Header.Add("Authorization", "Bearer "+cl.cfg.APIKey)
 	if req.TokenBudget > 0 {
-		httpReq.Header.Add("X-Token-Budget", fmt.Sprintf("%d", req.TokenBudget))
+		httpReq.Header.Set("X-Token-Budget", fmt.Sprintf("%d", req.TokenBudget))
 	}
 
 	if req.ContentFormat != ContentFormatDefault {
-		httpReq.Header.Add("X-Return-Format", string(req.ContentFormat))
+		httpReq.Header.Set("X-Return-Format", string(req.ContentFormat))
 	}
 
 	if req.BrowserEngine != BrowserEngineDefault {
-		httpReq.Header.Add("X-Engine", string(req.BrowserEngine))
+		httpReq.Header.Set("X-Engine", string(req.BrowserEngine))
 	}
 
 	if req.Timeout > 0 {
-		httpReq.Header.Add("X-Timeout", strconv.Itoa(req.Timeout))
+		httpReq.Header.Set("X-Timeout", strconv.Itoa(req.Timeout))
 	}
 
 	if req.GatherLinks != "" {
-		httpReq.Header.Add("X-With-Links-Summary", req.GatherLinks)
+		httpReq.Header.Set("X-With-Links-Summary", req.GatherLinks)
 	}
 
 	if req.RemoveAllImages {
-		httpReq.Header.Add("X-Retain-Images", "none")
+		httpReq.Header.Set("X-Retain-Images", "none")
 	}
 
 	if req.GatherImages != "" {
-		httpReq.Header.Add("X-With-Images-Summary", req.GatherImages)
+		httpReq.Header.Set("X-With-Images-Summary", req.GatherImages)
 	}
 
 	if req.ImageCaption {
-		httpReq.Header.Add("X-With-Generated-Alt", "true")
+		httpReq.Header.Set("X-With-Generated-Alt", "true")
 	}
 
 	if req.ProxyCountry != "" {
-		httpReq.Header.Add("X-Proxy", req.ProxyCountry)
+		httpReq.Header.Set("X-Proxy", req.ProxyCountry)
 	}
 
 	if req.ProxyURL != "" {
-		httpReq.Header.Add("X-Proxy-Url", req.ProxyURL)
+		httpReq.Header.Set("X-Proxy-Url", req.ProxyURL)
 	}
 
 	if req.BrowserLocale != "" {
-		httpReq.Header.Add("X-Locale", req.BrowserLocale)
+		httpReq.Header.Set("X-Locale", req.BrowserLocale)
 	}
 
 	if req.BypassCachedContent {
-		httpReq.Header.Add("X-No-Cache", "true")
+		httpReq.Header.Set("X-No-Cache", "true")
 	}
 
 	if req.TargetSelector != "" {
-		httpReq.Header.Add("X-Target-Selector", req.TargetSelector)
+		httpReq.Header.Set("X-Target-Selector", req.TargetSelector)
 	}
 
 	if req.WaitForSelector != "" {
-		httpReq.Header.Add("X-Wait-For-Selector", req.WaitForSelector)
+		httpReq.Header.Set("X-Wait-For-Selector", req.WaitForSelector)
 	}
 
 	if req.RemoveSelector != "" {
-		httpReq.Header.Add("X-Remove-Selector", req.RemoveSelector)
+		httpReq.Header.Set("X-Remove-Selector", req.RemoveSelector)
 	}
 
 	if req.WithIframe {
-		httpReq.Header.Add("X-With-Iframe", "true")
+		httpReq.Header.Set("X-With-Iframe", "true")
 	}
 
 	if req.WithShadowDom {
-		httpReq.Header.Add("X-With-Shadow-Dom", "true")
+		httpReq.Header.Set("X-With-Shadow-Dom", "true")
 	}
 
 	if req.RespondWith != "" {
-		httpReq.Header.Add("X-Respond-With", req.RespondWith)
+		httpReq.Header.Set("X-Respond-With", req.RespondWith)
 	}
 
 	if req.SetCookie != "" {
-		httpReq.Header.Add("X-Set-Cookie", req.SetCookie)
+		httpReq.Header.Set("X-Set-Cookie", req.SetCookie)
 	}
 
 	if req.DNT > 0 {
-		httpReq.Header.Add("DNT", strconv.Itoa(req.DNT))
+		httpReq.Header.Set("DNT", strconv.Itoa(req.DNT))
 	}
 
 	if req.NoGfm != "" {
-		httpReq.Header.Add("X-No-Gfm", req.NoGfm)
+		httpReq.Header.Set("X-No-Gfm", req.NoGfm)
+	}
+
+	if req.UserAgent != "" {
+		httpReq.Header.Set("X-User-Agent", req.UserAgent)
 	}
 
 	if req.RobotsTxt != "" {
-		httpReq.Header.Add("X-Robots-Txt", req.RobotsTxt)
+		httpReq.Header.Set("X-Robots-Txt", req.RobotsTxt)
 	}
 
 	if req.Base {
-		httpReq.Header.Add("X-Base", "final")
+		httpReq.Header.Set("X-Base", "final")
 	}
 
 	if req.MdHeadingStyle != "" {
-		httpReq.Header.Add("X-Md-Heading-Style", req.MdHeadingStyle)
+		httpReq.Header.Set("X-Md-Heading-Style", req.MdHeadingStyle)
 	}
 
 	if req.MdHr != "" {
-		httpReq.Header.Add("X-Md-Hr", req.MdHr)
+		httpReq.Header.Set("X-Md-Hr", req.MdHr)
 	}
 
 	if req.MdBulletListMarker != "" {
-		httpReq.Header.Add("X-Md-Bullet-List-Marker", req.MdBulletListMarker)
+		httpReq.Header.Set("X-Md-Bullet-List-Marker", req.MdBulletListMarker)
 	}
 
 	if req.MdEmDelimiter != "" {
-		httpReq.Header.Add("X-Md-Em-Delimiter", req.MdEmDelimiter)
+		httpReq.Header.Set("X-Md-Em-Delimiter", req.MdEmDelimiter)
 	}
 
 	if req.MdStrongDelimiter != "" {
-		httpReq.Header.Add("X-Md-Strong-Delimiter", req.MdStrongDelimiter)
+		httpReq.Header.Set("X-Md-Strong-Delimiter", req.MdStrongDelimiter)
 	}
 
 	if req.MdLinkStyle != "" {
-		httpReq.Header.Add("X-Md-Link-Style", req.MdLinkStyle)
+		httpReq.Header.Set("X-Md-Link-Style", req.MdLinkStyle)
 	}
 
 	if req.MdLinkReferenceStyle != "" {
-		httpReq.Header.Add("X-Md-Link-Reference-Style", req.MdLinkReferenceStyle)
+		httpReq.Header.Set("X-Md-Link-Reference-Style", req.MdLinkReferenceStyle)
 	}
 
 	if req.JSONResponse {
-		httpReq.Header.Add("Accept", "application/json")
+		httpReq.Header.Set("Accept", "application/json")
 	}
 }
 
-func (cl *Client) parseReaderResponse(body []byte, jsonResponse bool) (*ReaderResponse, error) {
+func (cl *Client) parseReaderResponse(body io.Reader, jsonResponse bool) (*ReaderResponse, error) {
 	if jsonResponse {
 		var structured StructuredReaderResponse
 
-		err := json.Unmarshal(body, &structured)
+		err := json.NewDecoder(body).Decode(&structured)
 		if err != nil {
 			return nil, fmt.Errorf("unmarshal response body: %w", err)
 		}
@@ -380,8 +456,13 @@ func (cl *Client) parseReaderResponse(body []byte, jsonResponse bool) (*ReaderRe
 		}, nil
 	}
 
+	var text strings.Builder
+	if _, err := io.Copy(&text, body); err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
 	return &ReaderResponse{
-		Text:       string(body),
+		Text:       text.String(),
 		Structured: nil,
 	}, nil
 }
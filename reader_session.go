@@ -0,0 +1,189 @@
+package jina
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoSessionCookies is returned by ReaderSession.Login when the login page set no cookies the
+// script could read.
+var ErrNoSessionCookies = errors.New("login set no cookies")
+
+// ReaderLogin describes the login flow of a ReaderSession.
+type ReaderLogin struct {
+	// URL is the page the login script runs on.
+	URL string
+
+	// Script logs in from the page, with {{name}} placeholders replaced by the matching Credentials
+	// as JavaScript string literals, e.g.
+	//
+	//	await fetch("/api/login", {method: "POST", body: JSON.stringify({user: {{user}}, pass: {{password}}})});
+	//
+	// The cookies are read once the script's promise settles, so it must log in without navigating
+	// away, e.g. with fetch rather than by submitting a form.
+	Script string
+
+	Credentials map[string]string
+
+	// TTL is how long the captured cookies are reused before logging in again. Defaults to 30 minutes.
+	TTL time.Duration
+}
+
+// ReaderSession reads pages behind a login. It runs the login flow through the Reader with
+// InjectPageScript, captures the cookies it results in and sends them with every read until they
+// expire, logging in again as needed.
+//
+// Only cookies visible to document.cookie can be captured, HttpOnly session cookies are not.
+type ReaderSession struct {
+	client *Client
+	login  ReaderLogin
+
+	mu      sync.Mutex
+	cookies []*http.Cookie
+	expires time.Time
+}
+
+// NewReaderSession returns a session logging in with login on first use.
+func (cl *Client) NewReaderSession(login ReaderLogin) *ReaderSession {
+	if login.TTL <= 0 {
+		login.TTL = 30 * time.Minute
+	}
+
+	return &ReaderSession{client: cl, login: login}
+}
+
+// Cookies returns the cookies of the session and when they expire, or nil before the first login.
+func (s *ReaderSession) Cookies() ([]*http.Cookie, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.cookies, s.expires
+}
+
+// Read reads req with the session's cookies, logging in first when they are missing or expired.
+// A SetCookie already set on req takes precedence.
+func (s *ReaderSession) Read(ctx context.Context, req ReaderRequest, opts ...CallOption) (*ReaderResponse, error) {
+	s.mu.Lock()
+	if s.cookies == nil || !s.client.cfg.Clock.Now().Before(s.expires) {
+		if err := s.loginLocked(ctx, opts); err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+	}
+	cookies := s.cookies
+	s.mu.Unlock()
+
+	if req.SetCookie == "" {
+		req.SetCookie = formatSetCookies(cookies)
+	}
+
+	return s.client.Reader(ctx, req, opts...)
+}
+
+// Login runs the login flow, replacing the session's cookies.
+func (s *ReaderSession) Login(ctx context.Context, opts ...CallOption) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.loginLocked(ctx, opts)
+}
+
+// sessionCookiesMarker wraps the cookies written into the login page by the capture script.
+const sessionCookiesMarker = "JINA_SESSION_COOKIES"
+
+var sessionCookiesPattern = regexp.MustCompile(sessionCookiesMarker + `\[([^\]]*)\]`)
+
+func (s *ReaderSession) loginLocked(ctx context.Context, opts []CallOption) error {
+	loginURL, err := url.Parse(s.login.URL)
+	if err != nil || loginURL.Host == "" {
+		return fmt.Errorf("invalid login URL %q", s.login.URL)
+	}
+	script, err := s.script()
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Reader(ctx, ReaderRequest{
+		URL:                 s.login.URL,
+		InjectPageScript:    script,
+		ContentFormat:       ContentFormatText,
+		WaitForSelector:     "#jina-session-cookies",
+		TargetSelector:      "#jina-session-cookies",
+		BypassCachedContent: true,
+	}, opts...)
+	if err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+
+	content := resp.Text
+	if resp.Structured != nil {
+		content = resp.Structured.Data.Content
+	}
+	match := sessionCookiesPattern.FindStringSubmatch(content)
+	if match == nil {
+		return errors.New("login: cookies not found in page, the login script may have navigated away")
+	}
+	raw, err := url.QueryUnescape(match[1])
+	if err != nil {
+		return fmt.Errorf("login: decode cookies: %w", err)
+	}
+	cookies, err := http.ParseCookie(raw)
+	if err != nil || len(cookies) == 0 {
+		return fmt.Errorf("login: %w", ErrNoSessionCookies)
+	}
+
+	for _, cookie := range cookies {
+		cookie.Domain = loginURL.Hostname()
+	}
+	s.cookies = cookies
+	s.expires = s.client.cfg.Clock.Now().Add(s.login.TTL)
+
+	return nil
+}
+
+var credentialPlaceholder = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// script renders the login script and appends the capture of document.cookie once it settled.
+func (s *ReaderSession) script() (string, error) {
+	var missing []string
+	rendered := credentialPlaceholder.ReplaceAllStringFunc(s.login.Script, func(placeholder string) string {
+		name := credentialPlaceholder.FindStringSubmatch(placeholder)[1]
+		value, ok := s.login.Credentials[name]
+		if !ok {
+			missing = append(missing, name)
+			return placeholder
+		}
+		literal, _ := json.Marshal(value)
+		return string(literal)
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("login script: missing credentials %s", strings.Join(missing, ", "))
+	}
+
+	return fmt.Sprintf(`(async () => {
+%s
+})().finally(() => {
+	const el = document.createElement("pre");
+	el.id = "jina-session-cookies";
+	el.textContent = "%s[" + encodeURIComponent(document.cookie) + "]";
+	document.body.appendChild(el);
+});`, rendered, sessionCookiesMarker), nil
+}
+
+// formatSetCookies formats cookies for ReaderRequest.SetCookie, separated by commas.
+func formatSetCookies(cookies []*http.Cookie) string {
+	values := make([]string, len(cookies))
+	for i, cookie := range cookies {
+		values[i] = cookie.String()
+	}
+
+	return strings.Join(values, ", ")
+}
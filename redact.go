@@ -0,0 +1,94 @@
+package jina
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Redacted replaces secrets in errors, logs and request dumps.
+const Redacted = "[REDACTED]"
+
+// sensitiveHeaders carry credentials and are never logged or dumped verbatim.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"X-Api-Key",
+	"X-Proxy-Url", // Reader and Search proxies, may embed user:pass@
+	"Cookie",
+	"Set-Cookie",
+	"X-Set-Cookie",
+}
+
+var (
+	// jinaKeyPattern matches Jina API keys embedded in free text, such as error bodies echoing the request.
+	jinaKeyPattern = regexp.MustCompile(`jina_[A-Za-z0-9_-]{16,}`)
+	// bearerPattern matches bearer credentials embedded in free text.
+	bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[^\s"',]+`)
+)
+
+// RedactHeaders returns a copy of h with credential headers replaced by Redacted,
+// safe to include in logs, request dumps and middleware payloads.
+func RedactHeaders(h http.Header) http.Header {
+	return redactHeaders(h, nil)
+}
+
+// redactHeaders redacts h like RedactHeaders, and also every header with a value containing one of
+// secrets, such as the header a custom Authenticator put the API key in.
+func redactHeaders(h http.Header, secrets []string) http.Header {
+	redacted := h.Clone()
+	for _, name := range sensitiveHeaders {
+		if _, ok := redacted[name]; ok {
+			redacted[name] = []string{Redacted}
+		}
+	}
+	for name, values := range redacted {
+		if slices.ContainsFunc(values, func(value string) bool { return containsSecret(value, secrets) }) {
+			redacted[name] = []string{Redacted}
+		}
+	}
+
+	return redacted
+}
+
+// containsSecret reports whether s contains one of secrets, ignoring those too short to be credentials.
+func containsSecret(s string, secrets []string) bool {
+	return slices.ContainsFunc(secrets, func(secret string) bool {
+		return len(secret) >= 8 && strings.Contains(s, secret)
+	})
+}
+
+// RedactString replaces Jina API keys and bearer credentials in s by Redacted.
+func RedactString(s string) string {
+	s = jinaKeyPattern.ReplaceAllString(s, Redacted)
+	return bearerPattern.ReplaceAllString(s, "${1}"+Redacted)
+}
+
+// redactSecrets redacts s with RedactString and additionally replaces every exact secret.
+func redactSecrets(s string, secrets []string) string {
+	for _, secret := range secrets {
+		if len(secret) >= 8 {
+			s = strings.ReplaceAll(s, secret, Redacted)
+		}
+	}
+	return RedactString(s)
+}
+
+// requestSecrets collects the credential values sent with req, in any auth scheme.
+func requestSecrets(req *http.Request) []string {
+	var secrets []string
+	for _, name := range sensitiveHeaders {
+		for _, value := range req.Header.Values(name) {
+			secrets = append(secrets, value)
+			if _, token, ok := strings.Cut(value, " "); ok {
+				secrets = append(secrets, token)
+			}
+		}
+	}
+	if apiKey := callAPIKey(req); apiKey != "" {
+		secrets = append(secrets, apiKey)
+	}
+
+	return secrets
+}
@@ -1,11 +1,9 @@
 package jina
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
 type RerankerModel string
@@ -33,7 +31,7 @@ type RerankInput struct {
 // It supports both simple text/string inputs and structured multimodal inputs via separate fields.
 // The MarshalJSON method ensures the correct JSON structure is sent to the API.
 type RerankRequest struct {
-	// Model is the identifier of the model to use.
+	// Model is the identifier of the model to use. Defaults to the client's WithDefaultReranker.
 	Model RerankerModel `json:"model"`
 
 	// Simple usage (Text or Image URL as string)
@@ -65,6 +63,60 @@ type RerankRequest struct {
 	// ReturnDocuments decides whether to return the document text/content.
 	// Default is true. Use pointer to distinguish omitted vs false.
 	ReturnDocuments *bool `json:"return_documents,omitempty"`
+
+	// Listwise options, supported by jina-reranker-v3 only
+
+	// Instruction steers the ranking with a task description, e.g. "Prefer recent official documentation".
+	Instruction string `json:"-"`
+
+	// WindowSize is the number of documents ranked together in one listwise pass.
+	// The API default is used when zero.
+	WindowSize int `json:"-"`
+
+	// WindowStride is the number of documents the listwise window advances between passes,
+	// at most WindowSize. The API default is used when zero.
+	WindowStride int `json:"-"`
+}
+
+// listwiseRerankers are the models accepting the listwise options of RerankRequest.
+var listwiseRerankers = map[RerankerModel]bool{
+	RerankerModelV3: true,
+}
+
+// UnsupportedOptionError is returned when a request sets an option its model does not support,
+// rather than letting the API silently ignore it.
+type UnsupportedOptionError struct {
+	Model  string
+	Option string
+}
+
+func (e *UnsupportedOptionError) Error() string {
+	return fmt.Sprintf("model %s does not support %s", e.Model, e.Option)
+}
+
+// validateListwise checks the listwise options against the model of the request.
+func (r RerankRequest) validateListwise() error {
+	options := []struct {
+		name string
+		set  bool
+	}{
+		{"instruction", r.Instruction != ""},
+		{"window_size", r.WindowSize != 0},
+		{"window_stride", r.WindowStride != 0},
+	}
+	for _, option := range options {
+		if option.set && !listwiseRerankers[r.Model] {
+			return &UnsupportedOptionError{Model: string(r.Model), Option: option.name}
+		}
+	}
+	if r.WindowSize < 0 || r.WindowStride < 0 {
+		return fmt.Errorf("window size and stride must not be negative")
+	}
+	if r.WindowSize > 0 && r.WindowStride > r.WindowSize {
+		return fmt.Errorf("window stride %d exceeds window size %d", r.WindowStride, r.WindowSize)
+	}
+
+	return nil
 }
 
 // MarshalJSON implements custom marshaling to map the distinct Go fields to the unified JSON API structure.
@@ -79,6 +131,15 @@ func (r RerankRequest) MarshalJSON() ([]byte, error) {
 	if r.ReturnDocuments != nil {
 		data["return_documents"] = r.ReturnDocuments
 	}
+	if r.Instruction != "" {
+		data["instruction"] = r.Instruction
+	}
+	if r.WindowSize > 0 {
+		data["window_size"] = r.WindowSize
+	}
+	if r.WindowStride > 0 {
+		data["window_stride"] = r.WindowStride
+	}
 
 	// 2. Handle Query
 	if r.QueryInput != nil {
@@ -100,6 +161,7 @@ func (r RerankRequest) MarshalJSON() ([]byte, error) {
 type RerankResponse struct {
 	Model   string         `json:"model"`
 	Usage   Usage          `json:"usage"`
+	Meta    Meta           `json:"-"`
 	Results []RerankResult `json:"results"`
 }
 
@@ -110,44 +172,30 @@ type RerankResult struct {
 }
 
 // Rerank calls the Jina Reranker API to rank documents based on relevance to the query.
-func (cl *Client) Rerank(ctx context.Context, req RerankRequest) (*RerankResponse, error) {
-	url := "https://api.jina.ai/v1/rerank"
-
-	jsonData, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+func (cl *Client) Rerank(ctx context.Context, req RerankRequest, opts ...CallOption) (*RerankResponse, error) {
+	if req.Model == "" {
+		req.Model = cl.cfg.DefaultRerankerModel
 	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "application/json")
-	if cl.cfg.APIKey != "" {
-		httpReq.Header.Set("Authorization", "Bearer "+cl.cfg.APIKey)
-	}
-
-	client := &http.Client{}
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	if err := cl.cfg.Limits.checkRerank(req); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		var errResp map[string]interface{}
-		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil {
-			return nil, fmt.Errorf("API error: %v", errResp)
+	return withModelFallback(cl, EndpointRerank, string(req.Model), func(model string) (*RerankResponse, error) {
+		req.Model = RerankerModel(model)
+		// Checked per model, as fallback models may not support the listwise options
+		if err := req.validateListwise(); err != nil {
+			return nil, err
 		}
-		return nil, fmt.Errorf("API error with status code: %d", resp.StatusCode)
-	}
 
-	var result RerankResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+		var result RerankResponse
+		if err := cl.postJSON(ctx, EndpointRerank, req, &result, newCallConfig(opts)); err != nil {
+			return nil, err
+		}
+		if result.Model == "" {
+			result.Model = model
+		}
+		cl.reportUsage(ctx, EndpointRerank, model, result.Usage)
 
-	return &result, nil
+		return &result, nil
+	})
 }
@@ -0,0 +1,107 @@
+package jina
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// RerankAggregation combines the scores a document received for several query variants.
+type RerankAggregation int
+
+const (
+	// RerankAggregationMean averages the relevance scores.
+	RerankAggregationMean RerankAggregation = iota
+
+	// RerankAggregationMax keeps the best relevance score.
+	RerankAggregationMax
+
+	// RerankAggregationRRF sums 1/(60+rank) over the variants (reciprocal rank fusion),
+	// which ignores score scales and rewards documents ranked well by many variants.
+	RerankAggregationRRF
+)
+
+// rrfK is the rank constant of reciprocal rank fusion, 60 as in the original paper.
+const rrfK = 60
+
+// RerankQueries reranks the documents of req against every query variant, e.g. paraphrases of a short
+// ambiguous query, and aggregates the scores per document. req.Query and req.QueryInput are ignored.
+// The variants are reranked concurrently; results are ordered by aggregated score and cut to req.TopN.
+// Usage is summed over all calls.
+func (cl *Client) RerankQueries(ctx context.Context, req RerankRequest, queries []string, aggregation RerankAggregation, opts ...CallOption) (*RerankResponse, error) {
+	if len(queries) == 0 {
+		return nil, errors.New("at least one query is required")
+	}
+
+	topN := req.TopN
+	req.TopN = 0 // All documents are needed to aggregate
+	req.QueryInput = nil
+
+	responses := make([]*RerankResponse, len(queries))
+	errs := make([]error, len(queries))
+	var wg sync.WaitGroup
+	for i, query := range queries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			variant := req
+			variant.Query = query
+			responses[i], errs[i] = cl.Rerank(ctx, variant, opts...)
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	type aggregate struct {
+		result RerankResult
+		sum    float64
+		best   float64
+	}
+	scores := map[int]*aggregate{}
+	result := &RerankResponse{Model: responses[0].Model}
+	for _, resp := range responses {
+		result.Usage.TotalTokens += resp.Usage.TotalTokens
+		result.Usage.PromptTokens += resp.Usage.PromptTokens
+
+		for rank, r := range resp.Results {
+			agg, ok := scores[r.Index]
+			if !ok {
+				agg = &aggregate{result: r, best: r.RelevanceScore}
+				scores[r.Index] = agg
+			}
+			agg.best = max(agg.best, r.RelevanceScore)
+			if aggregation == RerankAggregationRRF {
+				agg.sum += 1 / float64(rrfK+rank+1)
+			} else {
+				agg.sum += r.RelevanceScore
+			}
+		}
+	}
+
+	for _, agg := range scores {
+		switch aggregation {
+		case RerankAggregationMax:
+			agg.result.RelevanceScore = agg.best
+		case RerankAggregationRRF:
+			agg.result.RelevanceScore = agg.sum
+		default:
+			agg.result.RelevanceScore = agg.sum / float64(len(queries))
+		}
+		result.Results = append(result.Results, agg.result)
+	}
+	slices.SortFunc(result.Results, func(a, b RerankResult) int {
+		if c := cmp.Compare(b.RelevanceScore, a.RelevanceScore); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Index, b.Index)
+	})
+	if topN > 0 && len(result.Results) > topN {
+		result.Results = result.Results[:topN]
+	}
+
+	return result, nil
+}
@@ -0,0 +1,58 @@
+package jina
+
+import (
+	"fmt"
+	"io"
+)
+
+// WithMaxResponseBytes caps the size of non-streaming response bodies. Reading past the cap
+// aborts the call with a *ResponseTooLargeError, guarding against unexpectedly huge Reader
+// and full-content Search results. Zero, the default, disables the cap.
+func WithMaxResponseBytes(n int64) Option {
+	return func(cfg *config) {
+		cfg.MaxResponseBytes = n
+	}
+}
+
+// ResponseTooLargeError is returned when a response body exceeds the cap set with WithMaxResponseBytes.
+type ResponseTooLargeError struct {
+	Endpoint Endpoint
+	Limit    int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("%s response exceeds %d bytes", e.Endpoint, e.Limit)
+}
+
+// limitBody applies the configured response size cap to body.
+func (cl *Client) limitBody(endpoint Endpoint, body io.Reader) io.Reader {
+	if cl.cfg.MaxResponseBytes <= 0 {
+		return body
+	}
+
+	return &limitedReader{r: body, remaining: cl.cfg.MaxResponseBytes, endpoint: endpoint, limit: cl.cfg.MaxResponseBytes}
+}
+
+// limitedReader is like io.LimitedReader, but fails instead of reporting EOF once the limit is exceeded.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+
+	endpoint Endpoint
+	limit    int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	// Read one byte past the limit to tell a body of exactly limit bytes from a larger one
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n - 1, &ResponseTooLargeError{Endpoint: l.endpoint, Limit: l.limit}
+	}
+
+	return n, err
+}
@@ -0,0 +1,181 @@
+package jina
+
+import (
+	"cmp"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// RetryClass groups endpoints that share a retry policy.
+type RetryClass int
+
+const (
+	// RetryClassIdempotent holds the cheap endpoints that are safe to repeat:
+	// embeddings, rerank, classify, segment, reader and search.
+	RetryClassIdempotent RetryClass = iota
+
+	// RetryClassExpensive holds the long, costly generations, DeepSearch and VLM, and classifier
+	// training. Repeating them re-runs and re-bills the whole operation.
+	RetryClassExpensive
+)
+
+// RetryClass returns the retry class of the endpoint.
+func (e Endpoint) RetryClass() RetryClass {
+	switch e {
+	case EndpointDeepSearch, EndpointVLM, EndpointTrain:
+		return RetryClassExpensive
+	default:
+		return RetryClassIdempotent
+	}
+}
+
+// RetryPolicy controls how failed requests are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. Values below 2 disable retries.
+	MaxAttempts int
+
+	// Backoff is the wait before the first retry, doubled after each attempt up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// Jitter shortens each wait by a random fraction of up to Jitter (0 to 1), so concurrent callers
+	// failing together do not retry in lockstep.
+	Jitter float64
+
+	// MaxRetryAfter caps the wait a 429 response may ask for with its Retry-After or X-RateLimit-Reset
+	// headers, which is waited instead of the backoff. Longer waits, e.g. for an exhausted daily quota,
+	// return the 429 *APIError carrying the parsed limits instead. Defaults to a minute.
+	MaxRetryAfter time.Duration
+
+	// RetryOn decides whether an attempt is retried. resp is nil when err is not.
+	// Defaults to retrying network errors, 429 and 5xx responses.
+	RetryOn func(resp *http.Response, err error) bool
+}
+
+var (
+	// RetryPolicyAggressive suits the idempotent endpoints: quick, repeated retries of transient failures.
+	RetryPolicyAggressive = RetryPolicy{MaxAttempts: 5, Backoff: 250 * time.Millisecond, MaxBackoff: 10 * time.Second}
+
+	// RetryPolicyNone never repeats a request. It is the default of every class.
+	RetryPolicyNone = RetryPolicy{MaxAttempts: 1}
+)
+
+// WithRetry retries transient failures (network errors, 429 and 5xx responses) of the idempotent
+// endpoints, embeddings, rerank, classify, segment, reader and search, up to maxAttempts attempts in
+// total, with jittered exponential backoff starting at backoff and capped at 30 seconds. 429 responses
+// wait as long as their Retry-After or X-RateLimit-Reset headers ask, see RetryPolicy.MaxRetryAfter.
+// DeepSearch, VLM and training are not retried, see WithRetryPolicy.
+func WithRetry(maxAttempts int, backoff time.Duration) Option {
+	return WithRetryPolicy(RetryClassIdempotent, RetryPolicy{
+		MaxAttempts: maxAttempts,
+		Backoff:     backoff,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.5,
+	})
+}
+
+// WithRetryPolicy sets the retry policy of every endpoint in the class, e.g. aggressive retries
+// for embeddings while a failed DeepSearch is never re-run automatically:
+//
+//	jina.WithRetryPolicy(jina.RetryClassIdempotent, jina.RetryPolicyAggressive)
+func WithRetryPolicy(class RetryClass, policy RetryPolicy) Option {
+	return func(cfg *config) {
+		if cfg.RetryPolicies == nil {
+			cfg.RetryPolicies = make(map[RetryClass]RetryPolicy)
+		}
+		cfg.RetryPolicies[class] = policy
+	}
+}
+
+// WithIdempotencyKeys sends a random Idempotency-Key with every POST call that has none set with
+// WithIdempotencyKey. The key stays the same across the retries of a call, so the API and proxies in
+// between can deduplicate attempts that were received although their response was lost.
+func WithIdempotencyKeys() Option {
+	return func(cfg *config) {
+		cfg.IdempotencyKeys = true
+	}
+}
+
+func (cl *Client) retryPolicy(endpoint Endpoint) RetryPolicy {
+	if policy, ok := cl.cfg.RetryPolicies[endpoint.RetryClass()]; ok {
+		return policy
+	}
+
+	return RetryPolicyNone
+}
+
+// executeWithRetry sends the request, retrying it according to the endpoint's policy.
+func (cl *Client) executeWithRetry(endpoint Endpoint, req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	policy := cl.retryPolicy(endpoint)
+	backoff := policy.Backoff
+	for attempt := 1; ; attempt++ {
+		start := cl.cfg.Clock.Now()
+		resp, err := send(req)
+		cl.logAttempt(endpoint, req, attempt, start, resp, err)
+		if attempt >= policy.MaxAttempts || req.Context().Err() != nil || !policy.retryable(resp, err) {
+			return resp, err
+		}
+		wait := policy.jitter(backoff)
+		if resp != nil {
+			if throttled, ok := throttleWait(resp, cl.cfg.Clock.Now()); ok {
+				if throttled > cmp.Or(policy.MaxRetryAfter, time.Minute) {
+					return resp, nil
+				}
+				wait = throttled
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := cl.cfg.Clock.Sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		backoff *= 2
+		if policy.MaxBackoff > 0 {
+			backoff = min(backoff, policy.MaxBackoff)
+		}
+
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// throttleWait returns the wait a 429 response asks for, from Retry-After or else an exhausted
+// X-RateLimit window.
+func throttleWait(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if wait, ok := parseRetryAfter(resp.Header, now); ok {
+		return wait, true
+	}
+	if rl := parseRateLimit(resp.Header, now); rl.Limit > 0 && rl.Remaining == 0 && rl.Reset > 0 {
+		return rl.Reset, true
+	}
+
+	return 0, false
+}
+
+func (p RetryPolicy) jitter(wait time.Duration) time.Duration {
+	if p.Jitter <= 0 {
+		return wait
+	}
+
+	return wait - time.Duration(rand.Float64()*min(p.Jitter, 1)*float64(wait))
+}
+
+func (p RetryPolicy) retryable(resp *http.Response, err error) bool {
+	if p.RetryOn != nil {
+		return p.RetryOn(resp, err)
+	}
+	if err != nil {
+		var limitErr *LimitError
+		return !errors.As(err, &limitErr) && !errors.Is(err, ErrRateLimited) && !errors.Is(err, ErrCircuitOpen)
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
@@ -0,0 +1,188 @@
+package jina
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "seconds", value: "7", want: 7 * time.Second, wantOK: true},
+		{name: "zero seconds", value: "0", want: 0, wantOK: true},
+		{name: "HTTP date", value: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second, wantOK: true},
+		{name: "past HTTP date", value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOK: true},
+		{name: "missing", value: ""},
+		{name: "negative", value: "-1"},
+		{name: "garbage", value: "soon"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+			got, ok := parseRetryAfter(header, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name         string
+		header       func(now time.Time) http.Header
+		wantAttempts int
+		wantSleeps   []time.Duration
+	}{
+		{
+			name:         "seconds",
+			header:       func(time.Time) http.Header { return http.Header{"Retry-After": {"3"}} },
+			wantAttempts: 2,
+			wantSleeps:   []time.Duration{3 * time.Second},
+		},
+		{
+			name: "HTTP date",
+			header: func(now time.Time) http.Header {
+				return http.Header{"Retry-After": {now.Add(5 * time.Second).Format(http.TimeFormat)}}
+			},
+			wantAttempts: 2,
+			wantSleeps:   []time.Duration{5 * time.Second},
+		},
+		{
+			name: "exhausted rate limit window",
+			header: func(now time.Time) http.Header {
+				return http.Header{
+					"X-Ratelimit-Limit":     {"100"},
+					"X-Ratelimit-Remaining": {"0"},
+					"X-Ratelimit-Reset":     {strconv.FormatInt(now.Add(20*time.Second).Unix(), 10)},
+				}
+			},
+			wantAttempts: 2,
+			wantSleeps:   []time.Duration{20 * time.Second},
+		},
+		{
+			name:         "no header falls back to the backoff",
+			header:       func(time.Time) http.Header { return http.Header{} },
+			wantAttempts: 2,
+			wantSleeps:   []time.Duration{time.Second},
+		},
+		{
+			name:         "beyond MaxRetryAfter",
+			header:       func(time.Time) http.Header { return http.Header{"Retry-After": {"3600"}} },
+			wantAttempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			var attempts atomic.Int32
+			cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) == 1 {
+					for name, values := range tt.header(clock.Now()) {
+						w.Header()[name] = values
+					}
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.Write([]byte(`{"data":[]}`))
+			}, WithClock(clock), WithRetryPolicy(RetryClassIdempotent, RetryPolicy{MaxAttempts: 2, Backoff: time.Second}))
+
+			_, err := cl.Embeddings(context.Background(), EmbeddingsRequest{Input: []EmbeddingInput{{Text: "a"}}})
+			if tt.wantAttempts > 1 && err != nil {
+				t.Fatal(err)
+			}
+			if got := int(attempts.Load()); got != tt.wantAttempts {
+				t.Errorf("got %d attempts, want %d", got, tt.wantAttempts)
+			}
+			if sleeps := clock.Sleeps(); !slices.Equal(sleeps, tt.wantSleeps) {
+				t.Errorf("slept %v, want %v", sleeps, tt.wantSleeps)
+			}
+		})
+	}
+}
+
+func TestRetryClasses(t *testing.T) {
+	messages := []VLMMessage{NewVLMMessage("user", "hi")}
+	tests := []struct {
+		name         string
+		status       int
+		call         func(cl *Client) error
+		wantAttempts int
+	}{
+		{
+			name:   "idempotent endpoint retries 5xx",
+			status: http.StatusInternalServerError,
+			call: func(cl *Client) error {
+				_, err := cl.Embeddings(context.Background(), EmbeddingsRequest{Input: []EmbeddingInput{{Text: "a"}}})
+				return err
+			},
+			wantAttempts: 3,
+		},
+		{
+			name:   "idempotent endpoint does not retry 4xx",
+			status: http.StatusBadRequest,
+			call: func(cl *Client) error {
+				_, err := cl.Rerank(context.Background(), RerankRequest{Query: "q", Documents: []string{"a"}})
+				return err
+			},
+			wantAttempts: 1,
+		},
+		{
+			name:   "DeepSearch is not retried",
+			status: http.StatusInternalServerError,
+			call: func(cl *Client) error {
+				_, err := cl.DeepSearch(context.Background(), DeepSearchRequest{Messages: messages})
+				return err
+			},
+			wantAttempts: 1,
+		},
+		{
+			name:   "VLM is not retried",
+			status: http.StatusServiceUnavailable,
+			call: func(cl *Client) error {
+				_, err := cl.VLM(context.Background(), VLMRequest{Messages: messages})
+				return err
+			},
+			wantAttempts: 1,
+		},
+		{
+			name:   "training is not retried",
+			status: http.StatusTooManyRequests,
+			call: func(cl *Client) error {
+				_, err := cl.TrainClassifier(context.Background(), TrainClassifierRequest{Input: []TrainingExample{{Text: "a", Label: "x"}}})
+				return err
+			},
+			wantAttempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"detail":"failed"}`))
+			}, WithClock(newFakeClock()), WithRetry(3, time.Second))
+
+			if err := tt.call(cl); err == nil {
+				t.Fatal("call succeeded, want an error")
+			}
+			if got := int(attempts.Load()); got != tt.wantAttempts {
+				t.Errorf("got %d attempts, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package jina
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// RobotsChecker fetches and caches robots.txt files to check URLs locally before they are read,
+// for crawling policies stricter than the Reader's X-Robots-Txt header. It is safe for concurrent use.
+type RobotsChecker struct {
+	userAgent  string
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	cache map[string]*robotsEntry
+}
+
+type robotsEntry struct {
+	ready chan struct{}
+	rules *robotsRules
+}
+
+// NewRobotsChecker returns a checker matching rules for userAgent, fetching robots.txt with httpClient.
+// A nil httpClient uses http.DefaultClient.
+func NewRobotsChecker(userAgent string, httpClient *http.Client) *RobotsChecker {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &RobotsChecker{
+		userAgent:  userAgent,
+		httpClient: httpClient,
+		cache:      make(map[string]*robotsEntry),
+	}
+}
+
+// RobotsDisallowedError is returned by Reader when the URL is disallowed by the site's robots.txt.
+type RobotsDisallowedError struct {
+	URL       string
+	UserAgent string
+}
+
+func (e *RobotsDisallowedError) Error() string {
+	return fmt.Sprintf("%s is disallowed by robots.txt for %q", e.URL, e.UserAgent)
+}
+
+// WithRobotsCheck makes Reader check the URL against its site's robots.txt first, failing with a
+// *RobotsDisallowedError instead of reading disallowed pages. Combined with Batch, disallowed URLs
+// are skipped and reported in the results.
+func WithRobotsCheck(checker *RobotsChecker) CallOption {
+	return func(call *callConfig) {
+		call.robots = checker
+	}
+}
+
+// Allowed reports whether the checker's user agent may fetch rawURL.
+// Sites without a robots.txt (4xx) allow everything. When robots.txt cannot be fetched
+// (network errors, 5xx) everything is disallowed, as required by RFC 9309.
+func (rc *RobotsChecker) Allowed(ctx context.Context, rawURL string) (bool, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false, fmt.Errorf("parse URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false, fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+	}
+
+	rules, err := rc.rules(ctx, u)
+	if err != nil {
+		return false, err
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+
+	return rules.allowed(path), nil
+}
+
+func (rc *RobotsChecker) rules(ctx context.Context, u *url.URL) (*robotsRules, error) {
+	origin := u.Scheme + "://" + u.Host
+
+	rc.mu.Lock()
+	entry, ok := rc.cache[origin]
+	if !ok {
+		entry = &robotsEntry{ready: make(chan struct{})}
+		rc.cache[origin] = entry
+	}
+	rc.mu.Unlock()
+
+	if !ok {
+		rules, err := rc.fetch(ctx, origin)
+		if err != nil {
+			// Do not cache failures caused by the caller giving up
+			rc.mu.Lock()
+			delete(rc.cache, origin)
+			rc.mu.Unlock()
+			close(entry.ready)
+			return nil, err
+		}
+		entry.rules = rules
+		close(entry.ready)
+		return rules, nil
+	}
+
+	select {
+	case <-entry.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if entry.rules == nil {
+		return rc.rules(ctx, u)
+	}
+
+	return entry.rules, nil
+}
+
+// fetch downloads and parses robots.txt. Only context errors are returned, unreachable files become rules.
+func (rc *RobotsChecker) fetch(ctx context.Context, origin string) (*robotsRules, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+"/robots.txt", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", rc.userAgent)
+
+	resp, err := rc.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return &robotsRules{disallowAll: true}, nil
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 500:
+		return &robotsRules{disallowAll: true}, nil
+	case resp.StatusCode >= 400:
+		return &robotsRules{}, nil
+	}
+
+	// RFC 9309 allows crawlers to stop parsing after 500 KiB
+	return parseRobots(io.LimitReader(resp.Body, 500<<10), rc.userAgent), nil
+}
+
+type robotsRules struct {
+	disallowAll bool
+	rules       []robotsRule
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+// parseRobots keeps the rules of the most specific group matching userAgent, falling back to "*".
+func parseRobots(r io.Reader, userAgent string) *robotsRules {
+	agent := strings.ToLower(userAgent)
+	if name, _, ok := strings.Cut(agent, "/"); ok {
+		agent = name
+	}
+
+	var matched, wildcard []robotsRule
+	var matchedFound bool
+	var groupAgents []string
+	var groupRules []robotsRule
+	inRules := false
+
+	flush := func() {
+		for _, groupAgent := range groupAgents {
+			switch {
+			case groupAgent == "*":
+				wildcard = append(wildcard, groupRules...)
+			case agent != "" && agent == groupAgent:
+				matched = append(matched, groupRules...)
+				matchedFound = true
+			}
+		}
+		groupAgents, groupRules = nil, nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if inRules {
+				flush()
+				inRules = false
+			}
+			groupAgents = append(groupAgents, strings.ToLower(value))
+		case "allow", "disallow":
+			inRules = true
+			// An empty Disallow allows everything
+			if value != "" {
+				groupRules = append(groupRules, robotsRule{allow: key == "allow", pattern: value})
+			}
+		}
+	}
+	flush()
+
+	if matchedFound {
+		return &robotsRules{rules: matched}
+	}
+
+	return &robotsRules{rules: wildcard}
+}
+
+// allowed applies the longest matching rule, preferring Allow on ties.
+func (r *robotsRules) allowed(path string) bool {
+	if r.disallowAll {
+		return false
+	}
+
+	allowed, longest := true, -1
+	for _, rule := range r.rules {
+		if !robotsMatch(rule.pattern, path) {
+			continue
+		}
+		if len(rule.pattern) > longest || (len(rule.pattern) == longest && rule.allow) {
+			allowed, longest = rule.allow, len(rule.pattern)
+		}
+	}
+
+	return allowed
+}
+
+// robotsMatch matches path against a robots.txt pattern supporting the * and $ wildcards.
+func robotsMatch(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	for _, part := range parts[1:] {
+		i := strings.Index(rest, part)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(part):]
+	}
+	if !anchored {
+		return true
+	}
+
+	// With a trailing $ the last part must end the path
+	last := parts[len(parts)-1]
+	return rest == "" || (len(parts) > 1 && strings.HasSuffix(path, last))
+}
@@ -0,0 +1,118 @@
+package jina
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// SafeSearch is the adult-content filtering level of a search.
+type SafeSearch string
+
+const (
+	SafeSearchDefault  SafeSearch = ""         // The search engine's default
+	SafeSearchOff      SafeSearch = "off"      // No filtering
+	SafeSearchModerate SafeSearch = "moderate" // Filters explicit images and videos
+	SafeSearchStrict   SafeSearch = "strict"   // Filters all explicit results
+)
+
+// DomainCategorizer returns the content categories of a host, e.g. from a URL-categorization feed.
+type DomainCategorizer func(ctx context.Context, host string) ([]string, error)
+
+// BlockedResult is a search result dropped by WithBlockedDomains or WithBlockedCategories.
+type BlockedResult struct {
+	Result SearchResultData
+	Reason string
+}
+
+// WithBlockedDomains drops search results from the given domains and their subdomains.
+// Searches with blocked domains or categories always request a JSON response.
+func WithBlockedDomains(domains ...string) CallOption {
+	blocked := make([]string, len(domains))
+	for i, domain := range domains {
+		blocked[i] = strings.TrimPrefix(strings.ToLower(domain), ".")
+	}
+
+	return withResultFilter(func(_ context.Context, host string) (string, error) {
+		for _, domain := range blocked {
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return "domain " + domain + " is blocked", nil
+			}
+		}
+		return "", nil
+	})
+}
+
+// WithBlockedCategories drops search results whose host categorize places in one of the
+// given categories. categorize is called once per result with nothing but its host, a failing
+// categorizer fails the search.
+func WithBlockedCategories(categorize DomainCategorizer, categories ...string) CallOption {
+	return withResultFilter(func(ctx context.Context, host string) (string, error) {
+		hostCategories, err := categorize(ctx, host)
+		if err != nil {
+			return "", fmt.Errorf("categorize %s: %w", host, err)
+		}
+		for _, category := range hostCategories {
+			if slices.Contains(categories, category) {
+				return "category " + category + " is blocked", nil
+			}
+		}
+		return "", nil
+	})
+}
+
+// resultFilter returns why results from host are blocked, or "" to keep them.
+type resultFilter func(ctx context.Context, host string) (string, error)
+
+func withResultFilter(filter resultFilter) CallOption {
+	return func(call *callConfig) {
+		call.resultFilters = append(call.resultFilters, filter)
+	}
+}
+
+// filterResults drops the structured results blocked by the call's filters into resp.Blocked.
+// It fails for responses without structured results, which cannot be filtered.
+func (call *callConfig) filterResults(ctx context.Context, resp *SearchResponse) error {
+	if len(call.resultFilters) == 0 {
+		return nil
+	}
+	if resp.Structured == nil {
+		return errors.New("cannot filter search results of a text response")
+	}
+
+	kept := resp.Structured.Data[:0]
+	for _, result := range resp.Structured.Data {
+		reason, err := call.blockReason(ctx, result.URL)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			resp.Blocked = append(resp.Blocked, BlockedResult{Result: result, Reason: reason})
+			continue
+		}
+		kept = append(kept, result)
+	}
+	resp.Structured.Data = kept
+
+	return nil
+}
+
+func (call *callConfig) blockReason(ctx context.Context, rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return "unparsable URL", nil
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+
+	for _, filter := range call.resultFilters {
+		reason, err := filter(ctx, host)
+		if err != nil || reason != "" {
+			return reason, err
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,208 @@
+package jina
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// WithThrottleScheduler queues requests while an endpoint is throttled instead of failing them.
+// When a call receives a 429 with a Retry-After header the endpoint is paused until the window resets:
+// the throttled call is re-queued at the front, calls made in the meantime queue behind it, and once the
+// pause ends they are released one at a time in arrival order, each once the previous one got its
+// response, so a limit that is still exhausted throttles a single call again rather than all of them.
+// Each call is re-queued at most maxRetries times before its 429 is returned. Waiting calls give up
+// when their context is done.
+func WithThrottleScheduler(maxRetries int) Option {
+	return func(cfg *config) {
+		cfg.ThrottleScheduler = true
+		cfg.ThrottleRetries = maxRetries
+	}
+}
+
+type throttleScheduler struct {
+	maxRetries int
+	clock      Clock
+
+	mu     sync.Mutex
+	queues map[Endpoint]*throttleQueue
+}
+
+type throttleQueue struct {
+	pausedUntil time.Time
+	waiters     []chan struct{}
+	stopTimer   func() bool
+
+	// draining is set while the waiters are released one at a time after a pause, each handing off
+	// to the next once it got its response, so calls leave the queue in arrival order.
+	draining bool
+}
+
+func newThrottleScheduler(maxRetries int, clock Clock) *throttleScheduler {
+	return &throttleScheduler{
+		maxRetries: maxRetries,
+		clock:      clock,
+		queues:     make(map[Endpoint]*throttleQueue),
+	}
+}
+
+func (s *throttleScheduler) execute(cl *Client, endpoint Endpoint, req *http.Request) (*http.Response, error) {
+	front := false
+	for attempt := 0; ; attempt++ {
+		handoff, err := s.wait(req.Context(), endpoint, front)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := cl.do(req)
+		if err != nil {
+			handoff()
+			return nil, err
+		}
+
+		retryAfter, ok := parseRetryAfter(resp.Header, s.clock.Now())
+		if resp.StatusCode != http.StatusTooManyRequests || !ok || attempt >= s.maxRetries {
+			handoff()
+			return resp, nil
+		}
+		// Paused before handing off, so the queue stays held back behind the throttled call
+		s.pause(endpoint, retryAfter)
+		handoff()
+
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+		front = true
+	}
+}
+
+// wait blocks until the endpoint is no longer paused and the calls queued before this one got their
+// responses. Re-queued calls skip ahead of the queue, they were first in line when they got throttled.
+// The returned func must be called once the call got its response, to release the next one.
+func (s *throttleScheduler) wait(ctx context.Context, endpoint Endpoint, front bool) (func(), error) {
+	s.mu.Lock()
+	q := s.queue(endpoint)
+	if len(q.waiters) == 0 && !q.draining && !s.clock.Now().Before(q.pausedUntil) {
+		s.mu.Unlock()
+		return func() {}, nil
+	}
+
+	ready := make(chan struct{})
+	if front {
+		q.waiters = slices.Insert(q.waiters, 0, ready)
+	} else {
+		q.waiters = append(q.waiters, ready)
+	}
+	if q.stopTimer == nil && !q.draining {
+		s.schedule(q)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-ready:
+		return func() {
+			s.mu.Lock()
+			s.handoff(q)
+			s.mu.Unlock()
+		}, nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		if i := slices.Index(q.waiters, ready); i >= 0 {
+			q.waiters = slices.Delete(q.waiters, i, i+1)
+		} else {
+			// Released as the context ended, pass the turn on rather than stalling the queue
+			s.handoff(q)
+		}
+		s.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// pause holds back calls to the endpoint for at least d.
+func (s *throttleScheduler) pause(endpoint Endpoint, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q := s.queue(endpoint)
+	if until := s.clock.Now().Add(d); until.After(q.pausedUntil) {
+		q.pausedUntil = until
+	}
+	s.schedule(q)
+}
+
+// schedule arms the timer releasing the queue when the pause ends. s.mu must be held.
+func (s *throttleScheduler) schedule(q *throttleQueue) {
+	if q.stopTimer != nil {
+		q.stopTimer()
+	}
+	q.stopTimer = s.clock.AfterFunc(q.pausedUntil.Sub(s.clock.Now()), func() {
+		s.release(q)
+	})
+}
+
+func (s *throttleScheduler) release(q *throttleQueue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q.stopTimer = nil
+	// The pause may have been extended by another 429 since the timer was armed
+	if s.clock.Now().Before(q.pausedUntil) {
+		s.schedule(q)
+		return
+	}
+
+	q.draining = true
+	s.handoff(q)
+}
+
+// handoff releases the next waiter of a draining queue, or ends the draining once the queue is empty
+// or paused again, in which case the pause timer resumes it. s.mu must be held.
+func (s *throttleScheduler) handoff(q *throttleQueue) {
+	if !q.draining {
+		return
+	}
+	if len(q.waiters) == 0 || s.clock.Now().Before(q.pausedUntil) {
+		q.draining = false
+		if len(q.waiters) > 0 && q.stopTimer == nil {
+			s.schedule(q)
+		}
+		return
+	}
+
+	ready := q.waiters[0]
+	q.waiters = q.waiters[1:]
+	close(ready)
+}
+
+// queue returns the queue of the endpoint. s.mu must be held.
+func (s *throttleScheduler) queue(endpoint Endpoint) *throttleQueue {
+	q, ok := s.queues[endpoint]
+	if !ok {
+		q = &throttleQueue{}
+		s.queues[endpoint] = q
+	}
+
+	return q
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date, relative to now.
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,107 @@
+package jina
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestThrottleSchedulerFIFO(t *testing.T) {
+	const calls = 10
+	clock := newFakeClock()
+	var mu sync.Mutex
+	var order []string
+	throttled := false
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !throttled {
+			throttled = true
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		order = append(order, r.Header.Get("X-Call"))
+		w.Write([]byte(`{"data":[]}`))
+	}, WithThrottleScheduler(3), WithClock(clock))
+
+	queued := func(n int) func() bool {
+		return func() bool {
+			cl.scheduler.mu.Lock()
+			defer cl.scheduler.mu.Unlock()
+			return len(cl.scheduler.queue(EndpointEmbeddings).waiters) == n
+		}
+	}
+	var wg sync.WaitGroup
+	errs := make([]error, calls)
+	for i := range calls {
+		wg.Go(func() {
+			_, errs[i] = cl.Embeddings(context.Background(), EmbeddingsRequest{Input: []EmbeddingInput{{Text: "a"}}},
+				WithCallHeader("X-Call", strconv.Itoa(i)))
+		})
+		// The first call is throttled and re-queued, the others queue up behind it in turn
+		waitUntil(t, queued(i+1))
+	}
+	clock.Advance(time.Second)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("call %d: %v", i, err)
+		}
+	}
+	want := make([]string, calls)
+	for i := range want {
+		want[i] = strconv.Itoa(i)
+	}
+	if !slices.Equal(order, want) {
+		t.Errorf("calls were sent in order %v, want %v", order, want)
+	}
+}
+
+func TestThrottleSchedulerPausedAgain(t *testing.T) {
+	clock := newFakeClock()
+	var mu sync.Mutex
+	var attempts []string
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts = append(attempts, r.Header.Get("X-Call"))
+		// The first two attempts are throttled, the limit is still exhausted after the first pause
+		if len(attempts) <= 2 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"data":[]}`))
+	}, WithThrottleScheduler(3), WithClock(clock))
+
+	queued := func(n int) func() bool {
+		return func() bool {
+			cl.scheduler.mu.Lock()
+			defer cl.scheduler.mu.Unlock()
+			return len(cl.scheduler.queue(EndpointEmbeddings).waiters) == n
+		}
+	}
+	var wg sync.WaitGroup
+	for i := range 3 {
+		wg.Go(func() {
+			cl.Embeddings(context.Background(), EmbeddingsRequest{Input: []EmbeddingInput{{Text: "a"}}},
+				WithCallHeader("X-Call", strconv.Itoa(i)))
+		})
+		waitUntil(t, queued(i+1))
+	}
+	// Only the first call is released, its 429 holds the others back for another pause
+	clock.Advance(time.Second)
+	waitUntil(t, queued(3))
+	clock.Advance(time.Second)
+	wg.Wait()
+
+	if want := []string{"0", "0", "0", "1", "2"}; !slices.Equal(attempts, want) {
+		t.Errorf("attempts were sent in order %v, want %v", attempts, want)
+	}
+}
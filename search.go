@@ -1,13 +1,13 @@
 package jina
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 type SearchRequest struct {
@@ -30,6 +30,9 @@ type SearchRequest struct {
 	// PageOffset is the result offset for pagination.
 	PageOffset int `json:"page,omitempty"`
 
+	// SafeSearch sets the adult-content filtering level.
+	SafeSearch SafeSearch `json:"safe,omitempty"`
+
 	// Header Options
 
 	// ReadFullContent will enable visiting every URL in the search result and returning the full content using Reader
@@ -80,6 +83,13 @@ type SearchRequest struct {
 	// Locale controls the browser locale to render the page.
 	Locale string `json:"-"`
 
+	// TokenBudget specifies the maximum number of tokens the request may use, enforced by the API.
+	TokenBudget int `json:"-"`
+
+	// ResultTokenBudget truncates the Content of every structured result to about this many tokens
+	// (see ApproxTokens) client-side, so full-content results fit downstream context windows.
+	ResultTokenBudget int `json:"-"`
+
 	// EUCompliance, if true, uses EU infrastructure (eu.s.jina.ai).
 	EUCompliance bool `json:"-"`
 }
@@ -87,6 +97,26 @@ type SearchRequest struct {
 type SearchResponse struct {
 	Text       string                    // Raw text response
 	Structured *StructuredSearchResponse // Structured JSON response
+
+	// Blocked lists the structured results dropped by WithBlockedDomains and WithBlockedCategories.
+	Blocked []BlockedResult
+
+	// Meta describes the HTTP exchange the response was received in.
+	Meta Meta
+}
+
+// usage returns the tokens reported by a structured response. Text responses carry no usage.
+func (r *SearchResponse) usage() Usage {
+	if r.Structured == nil {
+		return Usage{}
+	}
+	tokens := r.Structured.Usage.Tokens
+	if tokens == 0 {
+		for _, result := range r.Structured.Data {
+			tokens += result.Usage.Tokens
+		}
+	}
+	return Usage{TotalTokens: tokens}
 }
 
 type StructuredSearchResponse struct {
@@ -103,127 +133,164 @@ type SearchResultData struct {
 	Description string `json:"description"`
 	URL         string `json:"url"`
 	Content     string `json:"content"`
+	Date        string `json:"date,omitempty"` // Publish date, when the search engine reports one
 	Usage       struct {
 		Tokens int `json:"tokens"`
 	} `json:"usage"`
+
+	// Truncated reports that Content was cut to the request's ResultTokenBudget.
+	Truncated bool `json:"-"`
 }
 
 // Search calls the Jina Search API to search the web.
-func (cl *Client) Search(ctx context.Context, req SearchRequest) (*SearchResponse, error) {
+func (cl *Client) Search(ctx context.Context, req SearchRequest, opts ...CallOption) (*SearchResponse, error) {
 	if req.Query == "" {
 		return nil, fmt.Errorf("query is required")
 	}
-	if cl.cfg.EUCompliance {
+	if cl.cfg.LocaleDetector != nil && req.LanguageCode == "" && req.CountryCode == "" {
+		if locale, ok := DetectLocale(req.Query, cl.cfg.LocaleDetector); ok {
+			req.SetLocale(locale)
+		}
+	}
+	if err := req.ValidateLocale(); err != nil {
+		return nil, err
+	}
+	call := newCallConfig(opts)
+	if call.euCompliance(cl.cfg) {
 		req.EUCompliance = true
 	}
+	// Result filters can only inspect structured results, text responses would pass unfiltered
+	if len(call.resultFilters) > 0 {
+		req.JSONResponse = true
+	}
 
-	requestURL := cl.buildSearchURL(req)
-
-	jsonData, err := json.Marshal(req)
+	requestURL, err := cl.resolveEndpointURL(EndpointSearch, req.EUCompliance)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, err
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewBuffer(jsonData))
+	reqBody, err := newRequestBody(req)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	defer reqBody.release()
+	if err := cl.cfg.Limits.checkRequestBody(reqBody); err != nil {
+		return nil, err
 	}
 
-	httpReq.Header.Set("Content-Type", "application/json")
-	cl.setSearchHeaders(httpReq, req)
-
-	client := &http.Client{}
-
-	resp, err := client.Do(httpReq)
+	httpReq, err := cl.newRequest(ctx, requestURL, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("do request: %w", err)
+		return nil, err
 	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(body))
+	cl.setSearchHeaders(httpReq, req)
+	if httpReq, err = cl.setCallHeaders(httpReq, call); err != nil {
+		return nil, err
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	var resp *SearchResponse
+	err = cl.sendStreaming(EndpointSearch, httpReq, call, func(body io.Reader) error {
+		if resp, err = cl.parseSearchResponse(body, req.JSONResponse); err != nil {
+			return err
+		}
+		call.usage = resp.usage()
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("read response body: %w", err)
+		return nil, err
 	}
-
-	return cl.parseSearchResponse(body, req.JSONResponse)
-}
-
-func (cl *Client) buildSearchURL(args SearchRequest) string {
-	baseURL := "https://s.jina.ai/"
-	if args.EUCompliance {
-		baseURL = "https://eu.s.jina.ai/"
+	resp.Meta = call.meta
+	// Blocked and truncated results were billed in full
+	cl.reportUsage(ctx, EndpointSearch, "", resp.usage())
+	if err := call.filterResults(ctx, resp); err != nil {
+		return nil, err
 	}
-	return baseURL
+	resp.truncate(req.ResultTokenBudget)
+
+	return resp, nil
 }
 
 func (cl *Client) setSearchHeaders(req *http.Request, args SearchRequest) {
-	req.Header.Add("Authorization", "Bearer "+cl.cfg.APIKey)
-
 	if args.JSONResponse {
-		req.Header.Add("Accept", "application/json")
+		req.Header.Set("Accept", "application/json")
 	}
 
 	if !args.ReadFullContent {
-		req.Header.Add("X-Respond-With", "no-content")
+		req.Header.Set("X-Respond-With", "no-content")
 	}
 	if args.Site != "" {
-		req.Header.Add("X-Site", args.Site)
+		req.Header.Set("X-Site", args.Site)
 	}
 	if args.WithLinksSummary {
-		req.Header.Add("X-With-Links-Summary", "true")
+		req.Header.Set("X-With-Links-Summary", "true")
 	}
 	if args.WithImagesSummary {
-		req.Header.Add("X-With-Images-Summary", "true")
+		req.Header.Set("X-With-Images-Summary", "true")
 	}
 	if args.RetainImages != "" {
-		req.Header.Add("X-Retain-Images", args.RetainImages)
+		req.Header.Set("X-Retain-Images", args.RetainImages)
 	}
 	if args.NoCache {
-		req.Header.Add("X-No-Cache", "true")
+		req.Header.Set("X-No-Cache", "true")
 	}
 	if args.WithGeneratedAlt {
-		req.Header.Add("X-With-Generated-Alt", "true")
+		req.Header.Set("X-With-Generated-Alt", "true")
 	}
 	if args.WithFavicon {
-		req.Header.Add("X-With-Favicon", "true")
+		req.Header.Set("X-With-Favicon", "true")
 	}
 	if args.ReturnFormat != "" {
-		req.Header.Add("X-Return-Format", args.ReturnFormat)
+		req.Header.Set("X-Return-Format", args.ReturnFormat)
 	}
 	if args.ReadFullContent && args.Engine != "" {
-		req.Header.Add("X-Engine", args.Engine)
+		req.Header.Set("X-Engine", args.Engine)
 	}
 	if args.WithFavicons {
-		req.Header.Add("X-With-Favicons", "true")
+		req.Header.Set("X-With-Favicons", "true")
 	}
 	if args.Timeout > 0 {
-		req.Header.Add("X-Timeout", strconv.Itoa(args.Timeout))
+		req.Header.Set("X-Timeout", strconv.Itoa(args.Timeout))
 	}
 	if args.SetCookie != "" {
-		req.Header.Add("X-Set-Cookie", args.SetCookie)
+		req.Header.Set("X-Set-Cookie", args.SetCookie)
 	}
 	if args.ProxyURL != "" {
-		req.Header.Add("X-Proxy-Url", args.ProxyURL)
+		req.Header.Set("X-Proxy-Url", args.ProxyURL)
 	}
 	if args.Locale != "" {
-		req.Header.Add("X-Locale", args.Locale)
+		req.Header.Set("X-Locale", args.Locale)
+	}
+	if args.TokenBudget > 0 {
+		req.Header.Set("X-Token-Budget", strconv.Itoa(args.TokenBudget))
+	}
+}
+
+// truncate cuts the content of every structured result to maxTokens.
+func (r *SearchResponse) truncate(maxTokens int) {
+	if maxTokens <= 0 || r.Structured == nil {
+		return
+	}
+	for i := range r.Structured.Data {
+		result := &r.Structured.Data[i]
+		pieces, _, _ := ApproxTokenSplitter(context.Background(), result.Content, maxTokens)
+		if len(pieces) > 1 {
+			result.Content = pieces[0]
+			result.Truncated = true
+		}
 	}
 }
 
-func (cl *Client) parseSearchResponse(body []byte, jsonResponse bool) (*SearchResponse, error) {
+func (cl *Client) parseSearchResponse(body io.Reader, jsonResponse bool) (*SearchResponse, error) {
 	if jsonResponse {
 		var structured StructuredSearchResponse
-		err := json.Unmarshal(body, &structured)
+		err := json.NewDecoder(body).Decode(&structured)
 		if err != nil {
 			return nil, fmt.Errorf("unmarshal response body: %w", err)
 		}
 		return &SearchResponse{Structured: &structured}, nil
 	}
-	return &SearchResponse{Text: string(body)}, nil
+	var text strings.Builder
+	if _, err := io.Copy(&text, body); err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+	return &SearchResponse{Text: text.String()}, nil
 }
@@ -1,11 +1,9 @@
 package jina
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
 type SegmenterRequest struct {
@@ -38,6 +36,7 @@ type SegmenterResponse struct {
 	NumTokens      int       `json:"num_tokens"`
 	Tokenizer      string    `json:"tokenizer"`
 	Usage          Usage     `json:"usage"`
+	Meta           Meta      `json:"-"`
 	NumChunks      int       `json:"num_chunks,omitempty"`
 	ChunkPositions [][]int   `json:"chunk_positions,omitempty"`
 	Tokens         [][]Token `json:"tokens,omitempty"` // List of chunks, each containing a list of Tokens
@@ -52,77 +51,68 @@ type Token struct {
 }
 
 // UnmarshalJSON implements custom unmarshaling for Token.
+// Elements are decoded directly into the Token fields to avoid intermediate interface{} values.
+// A null token is a no-op, a null ID list decodes to a token without IDs.
 func (t *Token) UnmarshalJSON(data []byte) error {
-	var raw []interface{}
+	if isJSONNull(data) {
+		return nil
+	}
+	if kind := jsonKind(data); kind != "array" {
+		return fmt.Errorf("invalid token format: expected [text, ids] array, got %s", kind)
+	}
+
+	var raw []json.RawMessage
 	if err := json.Unmarshal(data, &raw); err != nil {
-		return err
+		return fmt.Errorf("invalid token format: %w", err)
 	}
 	if len(raw) != 2 {
 		return fmt.Errorf("invalid token format: expected 2 elements, got %d", len(raw))
 	}
 
-	text, ok := raw[0].(string)
-	if !ok {
-		return fmt.Errorf("invalid token format: first element is not string")
+	if kind := jsonKind(raw[0]); kind != "string" {
+		return fmt.Errorf("invalid token format: first element is %s, not string", kind)
 	}
-	t.Text = text
-
-	// Second element is array of IDs
-	idsRaw, ok := raw[1].([]interface{})
-	if !ok {
-		return fmt.Errorf("invalid token format: second element is not array")
+	var token Token
+	if err := json.Unmarshal(raw[0], &token.Text); err != nil {
+		return fmt.Errorf("invalid token text: %w", err)
 	}
 
-	t.IDs = make([]int, len(idsRaw))
-	for i, idRaw := range idsRaw {
-		idFloat, ok := idRaw.(float64)
-		if !ok {
-			return fmt.Errorf("invalid token ID: not a number")
+	// Second element is array of IDs
+	if !isJSONNull(raw[1]) {
+		if kind := jsonKind(raw[1]); kind != "array" {
+			return fmt.Errorf("invalid token format: second element is %s, not array", kind)
+		}
+		var ids []json.RawMessage
+		if err := json.Unmarshal(raw[1], &ids); err != nil {
+			return fmt.Errorf("invalid token IDs: %w", err)
+		}
+		token.IDs = make([]int, len(ids))
+		for i, id := range ids {
+			if kind := jsonKind(id); kind != "number" {
+				return fmt.Errorf("invalid token ID %d: %s, not integer", i, kind)
+			}
+			if err := json.Unmarshal(id, &token.IDs[i]); err != nil {
+				return fmt.Errorf("invalid token ID %d: %s is not an integer in range", i, snippet(id))
+			}
 		}
-		t.IDs[i] = int(idFloat)
 	}
+
+	*t = token
 	return nil
 }
 
-// Segment calls the Jina Segmenter API to tokenize or chunk text.
-func (cl *Client) Segment(ctx context.Context, req SegmenterRequest) (*SegmenterResponse, error) {
-	url := "https://segment.jina.ai/"
-
-	jsonData, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "application/json")
-	if cl.cfg.APIKey != "" {
-		httpReq.Header.Set("Authorization", "Bearer "+cl.cfg.APIKey)
-	}
-
-	client := &http.Client{}
-	resp, err := client.Do(httpReq)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		var errResp map[string]interface{}
-		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil {
-			return nil, fmt.Errorf("API error: %v", errResp)
-		}
-		return nil, fmt.Errorf("API error with status code: %d", resp.StatusCode)
-	}
+// MarshalJSON encodes the token in the ["token_text", [id1, id2...]] format of the API.
+func (t Token) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{t.Text, t.IDs})
+}
 
+// Segment calls the Jina Segmenter API to tokenize or chunk text.
+func (cl *Client) Segment(ctx context.Context, req SegmenterRequest, opts ...CallOption) (*SegmenterResponse, error) {
 	var result SegmenterResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := cl.postJSON(ctx, EndpointSegment, req, &result, newCallConfig(opts)); err != nil {
+		return nil, err
 	}
+	cl.reportUsage(ctx, EndpointSegment, "", result.Usage)
 
 	return &result, nil
 }
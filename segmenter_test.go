@@ -0,0 +1,81 @@
+package jina
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// segmenterResponse is a response of the Segmenter API with return_tokens and return_chunks set.
+const segmenterResponse = `{
+	"num_tokens": 9,
+	"tokenizer": "cl100k_base",
+	"usage": {"tokens": 0},
+	"num_chunks": 2,
+	"chunk_positions": [[0, 13], [13, 36]],
+	"tokens": [
+		[["Jina", [41, 2259]], [" AI", [15592]], [":", [25]]],
+		[[" Your", [4718]], [" Search", [7694]], [" Foundation", [5114]], [",", [11]], [" Super", [7445]], ["é", [978]]]
+	],
+	"chunks": ["Jina AI: ", "Your Search Foundation, Supercharged!"]
+}`
+
+func FuzzTokenUnmarshal(f *testing.F) {
+	var resp struct {
+		Tokens [][]json.RawMessage `json:"tokens"`
+	}
+	if err := json.Unmarshal([]byte(segmenterResponse), &resp); err != nil {
+		f.Fatal(err)
+	}
+	for _, chunk := range resp.Tokens {
+		for _, token := range chunk {
+			f.Add([]byte(token))
+		}
+	}
+	for _, seed := range []string{
+		`["hello",[15339]]`,
+		`["hello",null]`,
+		`["",[]]`,
+		`null`,
+		`[null,null]`,
+		`["a",[null]]`,
+		`[["a",[1]]]`,
+		`["a",[[1,2],[3]]]`,
+		`[[[[]]]]`,
+		`["a",[1e400]]`,
+		`["a",[99999999999999999999999]]`,
+		`["a",[-9223372036854775809]]`,
+		`["a",[1.5]]`,
+		`["a",[1],2]`,
+		`{"text":"a"}`,
+		`"a"`,
+		``,
+		`nope`,
+		`["a",[1`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var token Token
+		if err := token.UnmarshalJSON(data); err != nil {
+			if !strings.HasPrefix(err.Error(), "invalid token") {
+				t.Errorf("UnmarshalJSON(%q) error %q does not report an invalid token", data, err)
+			}
+			return
+		}
+
+		encoded, err := json.Marshal(token)
+		if err != nil {
+			t.Fatalf("Marshal(%#v): %v", token, err)
+		}
+		var decoded Token
+		if err := decoded.UnmarshalJSON(encoded); err != nil {
+			t.Fatalf("UnmarshalJSON(%s) of re-encoded %q: %v", encoded, data, err)
+		}
+		if !reflect.DeepEqual(decoded, token) {
+			t.Errorf("round trip of %q through %s = %#v, want %#v", data, encoded, decoded, token)
+		}
+	})
+}
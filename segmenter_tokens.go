@@ -0,0 +1,141 @@
+package jina
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// TokenStream holds the tokens of a Segmenter response undecoded, decoding them on demand.
+// It keeps the compact JSON form in memory instead of a [][]Token, see Client.SegmentTokens.
+type TokenStream struct {
+	raw json.RawMessage
+	err error
+}
+
+// SegmentTokens calls the Segmenter API with ReturnTokens set, returning the response without Tokens
+// and a TokenStream to iterate them lazily.
+func (cl *Client) SegmentTokens(ctx context.Context, req SegmenterRequest, opts ...CallOption) (*SegmenterResponse, *TokenStream, error) {
+	req.ReturnTokens = true
+
+	var result struct {
+		SegmenterResponse
+		Tokens json.RawMessage `json:"tokens"`
+	}
+	if err := cl.postJSON(ctx, EndpointSegment, req, &result, newCallConfig(opts)); err != nil {
+		return nil, nil, err
+	}
+	cl.reportUsage(ctx, EndpointSegment, "", result.Usage)
+
+	return &result.SegmenterResponse, &TokenStream{raw: result.Tokens}, nil
+}
+
+// All returns an iterator over the chunk index and token of every token, in order.
+// Decoding stops at the first malformed token, reported by Err.
+func (s *TokenStream) All() iter.Seq2[int, Token] {
+	return func(yield func(int, Token) bool) {
+		s.err = s.walk(func(chunk, _ int, token Token) bool {
+			return yield(chunk, token)
+		})
+	}
+}
+
+// Chunk returns an iterator over the tokens of a single chunk.
+func (s *TokenStream) Chunk(chunk int) iter.Seq[Token] {
+	return func(yield func(Token) bool) {
+		s.err = s.walk(func(c, _ int, token Token) bool {
+			if c < chunk {
+				return true
+			}
+			return c == chunk && yield(token)
+		})
+	}
+}
+
+// Span returns the text of the tokens [start, end) of a chunk, decoding only up to end.
+func (s *TokenStream) Span(chunk, start, end int) (string, error) {
+	if start < 0 || end < start {
+		return "", fmt.Errorf("invalid token range [%d, %d)", start, end)
+	}
+
+	var b strings.Builder
+	n := 0
+	for token := range s.Chunk(chunk) {
+		if n >= end {
+			break
+		}
+		if n >= start {
+			b.WriteString(token.Text)
+		}
+		n++
+	}
+	if s.err != nil {
+		return "", s.err
+	}
+	if n < end {
+		return "", fmt.Errorf("token range [%d, %d) out of bounds for chunk %d with %d tokens", start, end, chunk, n)
+	}
+
+	return b.String(), nil
+}
+
+// Err returns the error that stopped the last iteration, if any.
+func (s *TokenStream) Err() error {
+	return s.err
+}
+
+// walk decodes the tokens one at a time, calling fn until it returns false.
+func (s *TokenStream) walk(fn func(chunk, index int, token Token) bool) error {
+	if len(s.raw) == 0 || string(s.raw) == "null" {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(s.raw))
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+	for chunk := 0; dec.More(); chunk++ {
+		if err := expectDelim(dec, '['); err != nil {
+			return err
+		}
+		for index := 0; dec.More(); index++ {
+			var token Token
+			if err := dec.Decode(&token); err != nil {
+				return fmt.Errorf("chunk %d token %d: %w", chunk, index, err)
+			}
+			if !fn(chunk, index, token) {
+				return nil
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("decode tokens: %w", err)
+	}
+	if tok != delim {
+		return fmt.Errorf("decode tokens: expected %v, got %v", delim, tok)
+	}
+
+	return nil
+}
+
+// JoinTokens reconstructs the text covered by tokens, e.g. a range of a SegmenterResponse chunk.
+func JoinTokens(tokens []Token) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString(token.Text)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package jina
+
+import "context"
+
+// The interfaces below each cover one capability of *Client, so applications can depend on, mock
+// and compose just the services they use:
+//
+//	type Indexer struct {
+//		embedder jina.Embedder
+//		reader   jina.PageReader
+//	}
+
+// Embedder creates embeddings, see Client.Embeddings.
+type Embedder interface {
+	Embeddings(ctx context.Context, req EmbeddingsRequest, opts ...CallOption) (*EmbeddingsResponse, error)
+}
+
+// Reranker ranks documents by relevance to a query, see Client.Rerank.
+type Reranker interface {
+	Rerank(ctx context.Context, req RerankRequest, opts ...CallOption) (*RerankResponse, error)
+}
+
+// Classifier classifies inputs, with zero-shot labels or trained classifiers, see Client.Classify
+// and Client.TrainClassifier.
+type Classifier interface {
+	Classify(ctx context.Context, req ClassificationRequest, opts ...CallOption) (*ClassificationResponse, error)
+	TrainClassifier(ctx context.Context, req TrainClassifierRequest, opts ...CallOption) (*TrainClassifierResponse, error)
+}
+
+// Segmenter tokenizes and chunks text, see Client.Segment.
+type Segmenter interface {
+	Segment(ctx context.Context, req SegmenterRequest, opts ...CallOption) (*SegmenterResponse, error)
+}
+
+// PageReader reads web pages and PDFs, see Client.Reader.
+type PageReader interface {
+	Reader(ctx context.Context, req ReaderRequest, opts ...CallOption) (*ReaderResponse, error)
+}
+
+// Searcher searches the web, see Client.Search.
+type Searcher interface {
+	Search(ctx context.Context, req SearchRequest, opts ...CallOption) (*SearchResponse, error)
+}
+
+// DeepSearcher researches questions with DeepSearch, see Client.DeepSearch and Client.DeepSearchStream.
+type DeepSearcher interface {
+	DeepSearch(ctx context.Context, req DeepSearchRequest, opts ...CallOption) (*DeepSearchResponse, error)
+	DeepSearchStream(ctx context.Context, req DeepSearchRequest, callback func(*DeepSearchResponse) error, opts ...CallOption) error
+}
+
+// VLMClient chats with the vision language model, see Client.VLM and Client.VLMStream.
+type VLMClient interface {
+	VLM(ctx context.Context, req VLMRequest, opts ...CallOption) (*VLMResponse, error)
+	VLMStream(ctx context.Context, req VLMRequest, callback func(*VLMResponse) error, opts ...CallOption) error
+}
+
+var (
+	_ Embedder     = (*Client)(nil)
+	_ Reranker     = (*Client)(nil)
+	_ Classifier   = (*Client)(nil)
+	_ Segmenter    = (*Client)(nil)
+	_ PageReader   = (*Client)(nil)
+	_ Searcher     = (*Client)(nil)
+	_ DeepSearcher = (*Client)(nil)
+	_ VLMClient    = (*Client)(nil)
+)
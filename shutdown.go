@@ -0,0 +1,95 @@
+package jina
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrClientClosed is returned by calls started after Client.Close, and by in-flight calls
+// aborted because Close ran out of time.
+var ErrClientClosed = errors.New("client closed")
+
+// Close shuts the client down gracefully: new calls fail with ErrClientClosed, in-flight calls
+// and streams run to completion, and idle connections are released. When ctx is done before
+// the in-flight calls finish, they are aborted with ErrClientClosed and Close returns ctx's error.
+// Only the connections of a transport the client created for itself are closed, the shared
+// transport and clients passed to WithHTTPClient or WithTransport may still be in use elsewhere.
+//
+// Close is safe to call more than once and concurrently with calls on the client.
+func (cl *Client) Close(ctx context.Context) error {
+	cl.drain.shutdown()
+
+	var err error
+	select {
+	case <-cl.drain.idle:
+	case <-ctx.Done():
+		err = ctx.Err()
+		cl.drain.abort(ErrClientClosed)
+		<-cl.drain.idle
+	}
+	if cl.transport != nil {
+		cl.transport.CloseIdleConnections()
+	}
+
+	return err
+}
+
+// drain tracks the in-flight calls of a client, so Close can wait for them.
+type drain struct {
+	mu       sync.Mutex
+	closed   bool
+	inflight sync.WaitGroup
+	idle     chan struct{} // Closed once the client is closed and no call is in flight
+	once     sync.Once
+
+	aborted context.Context
+	abort   context.CancelCauseFunc
+}
+
+func newDrain() *drain {
+	d := &drain{idle: make(chan struct{})}
+	d.aborted, d.abort = context.WithCancelCause(context.Background())
+
+	return d
+}
+
+// begin registers a call, returning its context, cancelled if Close aborts in-flight calls,
+// and the func to call with the call's error when it is done.
+func (d *drain) begin(ctx context.Context) (context.Context, func(error) error, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return nil, nil, ErrClientClosed
+	}
+	d.inflight.Add(1)
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(d.aborted, func() { cancel(context.Cause(d.aborted)) })
+
+	return ctx, func(err error) error {
+		stop()
+		if err != nil && !errors.Is(err, ErrClientClosed) && errors.Is(context.Cause(ctx), ErrClientClosed) {
+			err = fmt.Errorf("%w: %w", ErrClientClosed, err)
+		}
+		cancel(nil)
+		d.inflight.Done()
+		return err
+	}, nil
+}
+
+// shutdown stops accepting calls.
+func (d *drain) shutdown() {
+	d.mu.Lock()
+	d.closed = true
+	d.mu.Unlock()
+
+	d.once.Do(func() {
+		go func() {
+			d.inflight.Wait()
+			close(d.idle)
+		}()
+	})
+}
@@ -0,0 +1,152 @@
+package jina
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// SimilarityMetric is the pairwise score computed by SimilarityMatrix.
+type SimilarityMetric int
+
+const (
+	// SimilarityCosine is the cosine similarity. Zero vectors score 0.
+	SimilarityCosine SimilarityMetric = iota
+
+	// SimilarityDot is the dot product, equal to the cosine similarity for normalized embeddings and cheaper.
+	SimilarityDot
+)
+
+// SimilarityOption configures SimilarityMatrix.
+type SimilarityOption func(*similarityConfig)
+
+type similarityConfig struct {
+	metric  SimilarityMetric
+	workers int
+}
+
+// WithSimilarityMetric sets the metric. Defaults to SimilarityCosine.
+func WithSimilarityMetric(metric SimilarityMetric) SimilarityOption {
+	return func(cfg *similarityConfig) {
+		cfg.metric = metric
+	}
+}
+
+// WithSimilarityWorkers sets the number of goroutines computing the matrix. Defaults to GOMAXPROCS.
+func WithSimilarityWorkers(n int) SimilarityOption {
+	return func(cfg *similarityConfig) {
+		cfg.workers = n
+	}
+}
+
+// Block sizes of the tiled computation: a block of b vectors is reused for every row of an a block
+// while it is in cache.
+const (
+	similarityRowBlock = 32
+	similarityColBlock = 256
+)
+
+// SimilarityMatrix returns the len(a) x len(b) matrix of pairwise scores between the vectors of a and b,
+// e.g. to deduplicate or cluster embeddings. Pass the same slice twice for the self-similarity matrix.
+// All vectors must have the same dimensions.
+func SimilarityMatrix(a, b [][]float32, opts ...SimilarityOption) ([][]float32, error) {
+	cfg := &similarityConfig{workers: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	cfg.workers = max(cfg.workers, 1)
+
+	dims := -1
+	for k, vectors := range [][][]float32{a, b} {
+		name := [...]string{"a", "b"}[k]
+		for i, vec := range vectors {
+			if dims == -1 {
+				dims = len(vec)
+			}
+			if len(vec) != dims {
+				return nil, fmt.Errorf("%s[%d] has %d dimensions, expected %d", name, i, len(vec), dims)
+			}
+		}
+	}
+
+	// Cosine scores are dot products scaled by the inverse norms, computed once per vector
+	var invA, invB []float32
+	if cfg.metric == SimilarityCosine {
+		invA, invB = inverseNorms(a), inverseNorms(b)
+	}
+
+	scores := make([][]float32, len(a))
+	backing := make([]float32, len(a)*len(b))
+	for i := range scores {
+		scores[i] = backing[i*len(b) : (i+1)*len(b) : (i+1)*len(b)]
+	}
+
+	blocks := make(chan int)
+	var wg sync.WaitGroup
+	for range min(cfg.workers, (len(a)+similarityRowBlock-1)/similarityRowBlock) {
+		wg.Go(func() {
+			for start := range blocks {
+				end := min(start+similarityRowBlock, len(a))
+				for colStart := 0; colStart < len(b); colStart += similarityColBlock {
+					colEnd := min(colStart+similarityColBlock, len(b))
+					for i := start; i < end; i++ {
+						row := scores[i]
+						for j := colStart; j < colEnd; j++ {
+							score := dot(a[i], b[j])
+							if invA != nil {
+								score *= invA[i] * invB[j]
+							}
+							row[j] = score
+						}
+					}
+				}
+			}
+		})
+	}
+	for start := 0; start < len(a); start += similarityRowBlock {
+		blocks <- start
+	}
+	close(blocks)
+	wg.Wait()
+
+	return scores, nil
+}
+
+func inverseNorms(vectors [][]float32) []float32 {
+	inv := make([]float32, len(vectors))
+	for i, vec := range vectors {
+		if norm := l2Norm(vec); norm > 0 {
+			inv[i] = float32(1 / norm)
+		}
+	}
+
+	return inv
+}
+
+// dot is unrolled by four, which lets the compiler keep independent accumulators in registers.
+func dot(a, b []float32) float32 {
+	b = b[:len(a)]
+	var s0, s1, s2, s3 float32
+	i := 0
+	for ; i+4 <= len(a); i += 4 {
+		s0 += a[i] * b[i]
+		s1 += a[i+1] * b[i+1]
+		s2 += a[i+2] * b[i+2]
+		s3 += a[i+3] * b[i+3]
+	}
+	for ; i < len(a); i++ {
+		s0 += a[i] * b[i]
+	}
+
+	return s0 + s1 + s2 + s3
+}
+
+// CosineSimilarity returns the cosine similarity of two vectors of the same dimensions, 0 if either is zero.
+func CosineSimilarity(a, b []float32) float32 {
+	norm := l2Norm(a) * l2Norm(b)
+	if norm == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	return float32(float64(dot(a, b)) / norm)
+}
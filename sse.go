@@ -0,0 +1,105 @@
+package jina
+
+import (
+	"bytes"
+	"io"
+)
+
+// streamEvent is a server-sent event dispatched by readEvents.
+// Data is only valid until the callback it is passed to returns.
+type streamEvent struct {
+	Name string // The event: field, empty for unnamed ("message") events
+	ID   string // The last id: field of the stream so far, empty if none was sent
+	Data []byte // The data: lines, joined with newlines
+}
+
+// readEvents parses the server-sent event stream r, calling fn for every event until the [DONE]
+// event or the end of the stream. It follows the EventSource format: events end at a blank line,
+// multi-line data is joined with newlines, lines starting with ':' are comments (heartbeats), and lines
+// may end in CRLF, LF or CR. Events without data are skipped. An id: field sets the ID of the event and
+// of the following ones until the next id:, ids containing NUL are ignored. As reconnection is not
+// supported, retry is ignored. Unlike EventSource, an event left unterminated by the end of the stream is
+// still dispatched, as some servers close the stream right after the last data line.
+func readEvents(r io.Reader, fn func(streamEvent) error) error {
+	scanner, release := newLineScanner(r)
+	defer release()
+	scanner.Split(scanEventLines)
+
+	data := getBuffer()
+	defer putBuffer(data)
+	var name, id string
+	dispatch := func() (bool, error) {
+		if data.Len() == 0 {
+			name = ""
+			return false, nil
+		}
+		event := streamEvent{Name: name, ID: id, Data: bytes.TrimSuffix(data.Bytes(), []byte("\n"))}
+		name = ""
+		defer data.Reset()
+		if string(event.Data) == "[DONE]" {
+			return true, nil
+		}
+		return false, fn(event)
+	}
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			if done, err := dispatch(); done || err != nil {
+				return err
+			}
+			continue
+		}
+		if line[0] == ':' {
+			continue
+		}
+
+		field, value, _ := bytes.Cut(line, []byte(":"))
+		value = bytes.TrimPrefix(value, []byte(" "))
+		switch string(field) {
+		case "data":
+			data.Write(value)
+			data.WriteByte('\n')
+		case "event":
+			name = string(value)
+		case "id":
+			if bytes.IndexByte(value, 0) < 0 {
+				id = string(value)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	_, err := dispatch()
+
+	return err
+}
+
+// scanEventLines is a bufio.SplitFunc splitting at CRLF, LF or lone CR line endings.
+func scanEventLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\n' {
+			return i + 1, data[:i], nil
+		}
+		if i+1 < len(data) {
+			if data[i+1] == '\n' {
+				return i + 2, data[:i], nil
+			}
+			return i + 1, data[:i], nil
+		}
+		if atEOF {
+			return i + 1, data[:i], nil
+		}
+		// A CR at the end of the buffer may be the first half of a CRLF
+		return 0, nil, nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
@@ -0,0 +1,161 @@
+package jina
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReadEvents(t *testing.T) {
+	tests := []struct {
+		name   string
+		stream string
+		want   []streamEvent
+	}{
+		{
+			name:   "unnamed events",
+			stream: "data: a\n\ndata: b\n\n",
+			want:   []streamEvent{{Data: []byte("a")}, {Data: []byte("b")}},
+		},
+		{
+			name:   "named events",
+			stream: "event: think\ndata: a\n\ndata: b\n\n",
+			want:   []streamEvent{{Name: "think", Data: []byte("a")}, {Data: []byte("b")}},
+		},
+		{
+			name:   "multi-line data",
+			stream: "data: a\ndata:b\ndata:  c\n\n",
+			want:   []streamEvent{{Data: []byte("a\nb\n c")}},
+		},
+		{
+			name:   "empty data line",
+			stream: "data\ndata: a\n\n",
+			want:   []streamEvent{{Data: []byte("\na")}},
+		},
+		{
+			name:   "CRLF line endings",
+			stream: "event: x\r\ndata: a\r\ndata: b\r\n\r\ndata: c\r\n\r\n",
+			want:   []streamEvent{{Name: "x", Data: []byte("a\nb")}, {Data: []byte("c")}},
+		},
+		{
+			name:   "CR line endings",
+			stream: "data: a\rdata: b\r\rdata: c\r\r",
+			want:   []streamEvent{{Data: []byte("a\nb")}, {Data: []byte("c")}},
+		},
+		{
+			name:   "mixed line endings",
+			stream: "data: a\r\n\ndata: b\r\rdata: c\n\r\n",
+			want:   []streamEvent{{Data: []byte("a")}, {Data: []byte("b")}, {Data: []byte("c")}},
+		},
+		{
+			name:   "comments",
+			stream: ": heartbeat\n\n:\ndata: a\n: inside\n\n",
+			want:   []streamEvent{{Data: []byte("a")}},
+		},
+		{
+			name:   "events without data",
+			stream: "event: ping\n\ndata: a\n\n",
+			want:   []streamEvent{{Data: []byte("a")}},
+		},
+		{
+			name:   "ids carry over",
+			stream: "id: 1\ndata: a\n\ndata: b\n\nid: 2\n\ndata: c\n\nid\ndata: d\n\n",
+			want: []streamEvent{
+				{ID: "1", Data: []byte("a")},
+				{ID: "1", Data: []byte("b")},
+				{ID: "2", Data: []byte("c")},
+				{Data: []byte("d")},
+			},
+		},
+		{
+			name:   "ids with NUL ignored",
+			stream: "id: 1\ndata: a\n\nid: 2\x003\ndata: b\n\n",
+			want:   []streamEvent{{ID: "1", Data: []byte("a")}, {ID: "1", Data: []byte("b")}},
+		},
+		{
+			name:   "retry and unknown fields ignored",
+			stream: "retry: 1000\nfoo: bar\ndata: a\n\n",
+			want:   []streamEvent{{Data: []byte("a")}},
+		},
+		{
+			name:   "done",
+			stream: "data: a\n\ndata: [DONE]\n\ndata: b\n\n",
+			want:   []streamEvent{{Data: []byte("a")}},
+		},
+		{
+			name:   "unterminated at EOF",
+			stream: "data: a\n\nevent: x\ndata: b",
+			want:   []streamEvent{{Data: []byte("a")}, {Name: "x", Data: []byte("b")}},
+		},
+		{
+			name:   "unterminated CR at EOF",
+			stream: "data: a\r",
+			want:   []streamEvent{{Data: []byte("a")}},
+		},
+		{
+			name:   "empty stream",
+			stream: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reading a byte at a time splits CRLF line endings across reads
+			for _, r := range []io.Reader{strings.NewReader(tt.stream), iotest.OneByteReader(strings.NewReader(tt.stream))} {
+				var got []streamEvent
+				err := readEvents(r, func(event streamEvent) error {
+					event.Data = bytes.Clone(event.Data)
+					got = append(got, event)
+					return nil
+				})
+				if err != nil {
+					t.Fatalf("readEvents: %v", err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("readEvents(%q) = %q, want %q", tt.stream, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadEventsCallbackError(t *testing.T) {
+	errStop := errors.New("stop")
+	calls := 0
+	err := readEvents(strings.NewReader("data: a\n\ndata: b\n\n"), func(streamEvent) error {
+		calls++
+		return errStop
+	})
+	if err != errStop || calls != 1 {
+		t.Errorf("readEvents = %v after %d calls, want %v after 1", err, calls, errStop)
+	}
+}
+
+func BenchmarkReadEvents(b *testing.B) {
+	var stream bytes.Buffer
+	for i := range 256 {
+		fmt.Fprintf(&stream, ": heartbeat\r\nevent: chunk\r\ndata: {\"id\":\"%d\",\"choices\":[{\"delta\":{\"content\":\"token %d \"}}]}\r\n\r\n", i, i)
+	}
+	stream.WriteString("data: [DONE]\n\n")
+	data := stream.Bytes()
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for b.Loop() {
+		events := 0
+		err := readEvents(bytes.NewReader(data), func(streamEvent) error {
+			events++
+			return nil
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if events != 256 {
+			b.Fatalf("got %d events, want 256", events)
+		}
+	}
+}
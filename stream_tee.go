@@ -0,0 +1,30 @@
+package jina
+
+import "io"
+
+// WithStreamTee mirrors the raw event stream of a streaming call (DeepSearchStream, VLMStream) to w
+// as it is consumed, e.g. to record sessions for audit or replay. A failed write aborts the stream.
+func WithStreamTee(w io.Writer) CallOption {
+	return func(call *callConfig) {
+		call.streamTee = w
+	}
+}
+
+// WithStreamDataTee mirrors the data payload of every event of a streaming call to w, one JSON
+// document per line, before it is decoded and passed to the callback. A failed write aborts the stream.
+func WithStreamDataTee(w io.Writer) CallOption {
+	return func(call *callConfig) {
+		call.streamDataTee = w
+	}
+}
+
+func (call *callConfig) teeData(data []byte) error {
+	if call.streamDataTee == nil {
+		return nil
+	}
+	if _, err := call.streamDataTee.Write(data); err != nil {
+		return err
+	}
+	_, err := io.WriteString(call.streamDataTee, "\n")
+	return err
+}
@@ -0,0 +1,250 @@
+package jina
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// StructEmbedderOption configures a StructEmbedder.
+type StructEmbedderOption func(*structEmbedderConfig)
+
+type structEmbedderConfig struct {
+	template string
+	model    EmbeddingModel
+	task     EmbeddingTask
+}
+
+// WithStructTemplate renders the text embedded for an item with a text/template executed on the item,
+// e.g. "{{.Title}}\n\n{{.Body}}", instead of joining its embed fields.
+func WithStructTemplate(text string) StructEmbedderOption {
+	return func(cfg *structEmbedderConfig) {
+		cfg.template = text
+	}
+}
+
+// WithStructModel sets the embedding model. Defaults to the client's default embedding model.
+func WithStructModel(model EmbeddingModel) StructEmbedderOption {
+	return func(cfg *structEmbedderConfig) {
+		cfg.model = model
+	}
+}
+
+// WithStructTask sets the embedding task. Defaults to EmbeddingTaskRetrievalPassage.
+func WithStructTask(task EmbeddingTask) StructEmbedderOption {
+	return func(cfg *structEmbedderConfig) {
+		cfg.task = task
+	}
+}
+
+// StructEmbedder embeds Go structs from their tagged fields, so domain objects can be indexed without
+// assembling strings by hand:
+//
+//	type Article struct {
+//		ID     string   `jina:"meta,id"`
+//		Title  string   `jina:"embed"`
+//		Body   string   `jina:"embed"`
+//		Tags   []string `jina:"embed,meta"`
+//		Author string   `jina:"meta,author"`
+//	}
+//
+// Fields tagged embed make up the embedded text, joined by blank lines in field order unless
+// WithStructTemplate is set. Fields tagged meta are copied to StructEmbedding.Metadata, keyed by the
+// name following meta or else the field name, for vector stores storing payloads next to vectors.
+type StructEmbedder[T any] struct {
+	client   *Client
+	cfg      structEmbedderConfig
+	template *template.Template
+
+	embedFields []int
+	metaFields  []structMetaField
+}
+
+type structMetaField struct {
+	index int
+	key   string
+}
+
+// StructEmbedding is the embedding of an item of a StructEmbedder.
+type StructEmbedding[T any] struct {
+	Item      T
+	Text      string // The embedded text
+	Embedding []float32
+	Metadata  map[string]any
+}
+
+// NewStructEmbedder returns an embedder for the struct type T, or *T. It fails when T is not a struct,
+// has no embed fields and no template, or the template does not parse.
+func NewStructEmbedder[T any](client *Client, opts ...StructEmbedderOption) (*StructEmbedder[T], error) {
+	e := &StructEmbedder[T]{
+		client: client,
+		cfg:    structEmbedderConfig{task: EmbeddingTaskRetrievalPassage},
+	}
+	for _, opt := range opts {
+		opt(&e.cfg)
+	}
+
+	typ := structType(reflect.TypeFor[T]())
+	if typ == nil {
+		return nil, fmt.Errorf("%v is not a struct", reflect.TypeFor[T]())
+	}
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		tag, ok := field.Tag.Lookup("jina")
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		for j, part := range parts {
+			switch part {
+			case "embed":
+				e.embedFields = append(e.embedFields, i)
+			case "meta":
+				key := field.Name
+				// A following part that is not an option names the metadata key
+				if j+1 < len(parts) && parts[j+1] != "embed" && parts[j+1] != "meta" {
+					key = parts[j+1]
+				}
+				e.metaFields = append(e.metaFields, structMetaField{index: i, key: key})
+			}
+		}
+	}
+
+	if e.cfg.template != "" {
+		tmpl, err := template.New("struct").Option("missingkey=error").Parse(e.cfg.template)
+		if err != nil {
+			return nil, fmt.Errorf("parse template: %w", err)
+		}
+		e.template = tmpl
+	} else if len(e.embedFields) == 0 {
+		return nil, fmt.Errorf("%v has no fields tagged jina:\"embed\"", typ)
+	}
+
+	return e, nil
+}
+
+// Text returns the text embedded for item.
+func (e *StructEmbedder[T]) Text(item T) (string, error) {
+	if e.template != nil {
+		var b strings.Builder
+		if err := e.template.Execute(&b, item); err != nil {
+			return "", fmt.Errorf("render template: %w", err)
+		}
+		return b.String(), nil
+	}
+
+	value := structValue(reflect.ValueOf(item))
+	if !value.IsValid() {
+		return "", errors.New("nil item")
+	}
+	var parts []string
+	for _, i := range e.embedFields {
+		if text := formatStructField(value.Field(i)); text != "" {
+			parts = append(parts, text)
+		}
+	}
+
+	return strings.Join(parts, "\n\n"), nil
+}
+
+// Metadata returns the meta fields of item, leaving out nil pointers. It is nil when T has no meta fields.
+func (e *StructEmbedder[T]) Metadata(item T) map[string]any {
+	value := structValue(reflect.ValueOf(item))
+	if len(e.metaFields) == 0 || !value.IsValid() {
+		return nil
+	}
+
+	metadata := make(map[string]any, len(e.metaFields))
+	for _, field := range e.metaFields {
+		// Pointers are dereferenced so stores get plain values, nil ones are left out
+		if v := structValue(value.Field(field.index)); v.IsValid() {
+			metadata[field.key] = v.Interface()
+		}
+	}
+
+	return metadata
+}
+
+// Embed embeds items in one Embeddings call, returning one embedding per item in item order.
+// Use Batch or the jobs package to embed more items than fit a single call.
+func (e *StructEmbedder[T]) Embed(ctx context.Context, items []T, opts ...CallOption) ([]StructEmbedding[T], error) {
+	results := make([]StructEmbedding[T], len(items))
+	inputs := make([]EmbeddingInput, len(items))
+	for i, item := range items {
+		text, err := e.Text(item)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		results[i] = StructEmbedding[T]{Item: item, Text: text, Metadata: e.Metadata(item)}
+		inputs[i] = NewEmbeddingInputText(text)
+	}
+
+	resp, err := e.client.Embeddings(ctx, EmbeddingsRequest{
+		Model: e.cfg.model,
+		Input: inputs,
+		Task:  e.cfg.task,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, data := range resp.Data {
+		if data.Index < 0 || data.Index >= len(results) {
+			return nil, fmt.Errorf("response index %d out of range", data.Index)
+		}
+		results[data.Index].Embedding = data.Embedding
+	}
+
+	return results, nil
+}
+
+// structType returns typ, or the type it points to, when it is a struct.
+func structType(typ reflect.Type) reflect.Type {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return typ
+}
+
+// structValue dereferences pointers, returning the zero Value for nil.
+func structValue(value reflect.Value) reflect.Value {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		return value.Elem()
+	}
+
+	return value
+}
+
+// formatStructField formats an embed field, joining slices with commas.
+func formatStructField(value reflect.Value) string {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		parts := make([]string, 0, value.Len())
+		for i := range value.Len() {
+			if part := formatStructField(value.Index(i)); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+	if value.IsZero() {
+		return ""
+	}
+
+	return strings.TrimSpace(fmt.Sprint(value.Interface()))
+}
@@ -0,0 +1,87 @@
+package jina
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// WithDialTimeout sets the maximum time to establish a TCP connection, so calls fail fast
+// when the API is unreachable, whatever their total timeout.
+func WithDialTimeout(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.DialTimeout = d
+	}
+}
+
+// WithResponseHeaderTimeout sets the maximum time to wait for the response headers once the
+// request is sent (time to first byte). Non-streaming DeepSearch calls only respond once the
+// answer is complete, use DeepSearchStream or a generous value when calling it.
+func WithResponseHeaderTimeout(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.ResponseHeaderTimeout = d
+	}
+}
+
+// DefaultTimeouts bound the total duration of calls whose context has no deadline, so they cannot hang
+// forever. Timeouts set with WithEndpointTimeout, WithTimeouts or WithTimeout replace them.
+var DefaultTimeouts = map[Endpoint]time.Duration{
+	EndpointEmbeddings: time.Minute,
+	EndpointRerank:     time.Minute,
+	EndpointClassify:   time.Minute,
+	EndpointSegment:    time.Minute,
+	EndpointReader:     3 * time.Minute,
+	EndpointSearch:     3 * time.Minute,
+	EndpointTrain:      5 * time.Minute,
+	EndpointVLM:        5 * time.Minute,
+	EndpointDeepSearch: 15 * time.Minute,
+}
+
+// WithEndpointTimeout bounds the total duration of every call to the endpoint, including
+// time spent queued and reading the response. The call's context still applies when it is shorter.
+//
+//	jina.NewClient(
+//		jina.WithDialTimeout(5*time.Second),
+//		jina.WithEndpointTimeout(jina.EndpointEmbeddings, 30*time.Second),
+//		jina.WithEndpointTimeout(jina.EndpointDeepSearch, 10*time.Minute),
+//	)
+//
+// A timeout of zero or less disables the endpoint's entry in DefaultTimeouts.
+func WithEndpointTimeout(endpoint Endpoint, d time.Duration) Option {
+	return WithTimeouts(map[Endpoint]time.Duration{endpoint: d})
+}
+
+// WithTimeouts sets the timeouts of several endpoints at once, see WithEndpointTimeout:
+//
+//	jina.WithTimeouts(map[jina.Endpoint]time.Duration{
+//		jina.EndpointEmbeddings: 10 * time.Second,
+//		jina.EndpointDeepSearch: 10 * time.Minute,
+//	})
+func WithTimeouts(timeouts map[Endpoint]time.Duration) Option {
+	return func(cfg *config) {
+		if cfg.EndpointTimeouts == nil {
+			cfg.EndpointTimeouts = make(map[Endpoint]time.Duration)
+		}
+		for endpoint, d := range timeouts {
+			cfg.EndpointTimeouts[endpoint] = d
+		}
+	}
+}
+
+// withTimeout returns req bound to the call's timeout, else the endpoint's configured timeout, else its
+// entry in DefaultTimeouts when the call's context has no deadline.
+func (cl *Client) withTimeout(endpoint Endpoint, req *http.Request, call *callConfig) (*http.Request, context.CancelFunc) {
+	timeout, ok := call.timeout, call.timeout > 0
+	if !ok {
+		timeout, ok = cl.cfg.EndpointTimeouts[endpoint]
+	}
+	if _, deadline := req.Context().Deadline(); !ok && !deadline {
+		timeout, ok = DefaultTimeouts[endpoint]
+	}
+	if !ok || timeout <= 0 {
+		return req, func() {}
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	return req.WithContext(ctx), cancel
+}
@@ -0,0 +1,244 @@
+package jina
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TruncationMode controls the client-side handling of text inputs longer than the model's context,
+// see WithInputTruncation.
+type TruncationMode int
+
+const (
+	// TruncationNone sends inputs as is, leaving over-long inputs to the API's Truncate behavior.
+	TruncationNone TruncationMode = iota
+
+	// TruncationHead keeps the first MaxTokens tokens of over-long inputs.
+	TruncationHead
+
+	// TruncationWindows splits over-long inputs into windows of MaxTokens tokens, embeds every window
+	// and returns the L2 normalized mean of the window embeddings as the input's embedding.
+	// Requires float single-vector embeddings.
+	TruncationWindows
+)
+
+// ModelMaxTokens is the maximum input length, in tokens, of the embedding models.
+var ModelMaxTokens = map[EmbeddingModel]int{
+	EmbeddingModelV4:       32768,
+	EmbeddingModelV3:       8192,
+	EmbeddingModelClipV2:   8192,
+	EmbeddingModelCode0_5B: 32768,
+	EmbeddingModelCode1_5B: 32768,
+}
+
+// TokenSplitter splits text into consecutive pieces of at most maxTokens tokens, returning the pieces
+// and the total number of tokens of text. Text within the budget is returned as a single piece.
+type TokenSplitter func(ctx context.Context, text string, maxTokens int) (pieces []string, tokens int, err error)
+
+// InputTruncation configures client-side truncation of embedding inputs.
+type InputTruncation struct {
+	Mode TruncationMode
+
+	// MaxTokens is the token budget of an input. Defaults to the model's ModelMaxTokens,
+	// inputs of models missing from the table are not truncated.
+	MaxTokens int
+
+	// Split measures and splits inputs. Defaults to ApproxTokenSplitter, use Client.SegmenterSplitter
+	// for exact counts at the cost of a Segmenter call per long input.
+	Split TokenSplitter
+}
+
+// WithInputTruncation measures the text inputs of Embeddings calls and truncates or windows the ones
+// exceeding the model's context before they are sent, instead of relying on the API to silently drop
+// the tail or reject the call. What was cut is reported in EmbeddingsResponse.Truncated.
+func WithInputTruncation(truncation InputTruncation) Option {
+	return func(cfg *config) {
+		cfg.InputTruncation = truncation
+	}
+}
+
+// TruncatedInput reports an input shortened by WithInputTruncation.
+type TruncatedInput struct {
+	Index   int // Index of the input in the request
+	Tokens  int // Tokens of the original input
+	Kept    int // Tokens sent, all of them with TruncationWindows
+	Windows int // Windows embedded, 1 with TruncationHead
+}
+
+// ApproxTokenSplitter splits text using ApproxTokens, cutting pieces at whitespace where possible.
+func ApproxTokenSplitter(_ context.Context, text string, maxTokens int) ([]string, int, error) {
+	tokens := ApproxTokens(text)
+	if tokens <= maxTokens {
+		return []string{text}, tokens, nil
+	}
+
+	maxRunes := maxTokens * 4
+	var pieces []string
+	for text != "" {
+		end := len(text)
+		if utf8.RuneCountInString(text) > maxRunes {
+			end = runeOffset(text, maxRunes)
+			// Prefer a cut at whitespace in the second half of the piece
+			if cut := strings.LastIndexFunc(text[:end], unicode.IsSpace); cut > end/2 {
+				end = cut + 1
+			}
+		}
+		pieces = append(pieces, text[:end])
+		text = text[end:]
+	}
+
+	return pieces, tokens, nil
+}
+
+func runeOffset(text string, n int) int {
+	for i := range text {
+		if n == 0 {
+			return i
+		}
+		n--
+	}
+
+	return len(text)
+}
+
+// SegmenterSplitter returns a TokenSplitter counting tokens exactly with the Segmenter API and the given
+// tokenizer (empty for the default). Texts with fewer bytes than the budget are not sent to the Segmenter.
+func (cl *Client) SegmenterSplitter(tokenizer string, opts ...CallOption) TokenSplitter {
+	return func(ctx context.Context, text string, maxTokens int) ([]string, int, error) {
+		// Every token is at least a byte long, so short texts cannot exceed the budget
+		if len(text) <= maxTokens {
+			return []string{text}, ApproxTokens(text), nil
+		}
+
+		resp, tokens, err := cl.SegmentTokens(ctx, SegmenterRequest{Content: text, Tokenizer: tokenizer}, opts...)
+		if err != nil {
+			return nil, 0, fmt.Errorf("count tokens: %w", err)
+		}
+		if resp.NumTokens <= maxTokens {
+			return []string{text}, resp.NumTokens, nil
+		}
+
+		var pieces []string
+		var piece strings.Builder
+		n := 0
+		for _, token := range tokens.All() {
+			if n == maxTokens {
+				pieces = append(pieces, piece.String())
+				piece.Reset()
+				n = 0
+			}
+			piece.WriteString(token.Text)
+			n++
+		}
+		if err := tokens.Err(); err != nil {
+			return nil, 0, fmt.Errorf("count tokens: %w", err)
+		}
+		if piece.Len() > 0 {
+			pieces = append(pieces, piece.String())
+		}
+
+		return pieces, resp.NumTokens, nil
+	}
+}
+
+// truncateInputs applies the configured truncation to req. With TruncationWindows it returns, for every
+// input of the original request, the range of the expanded inputs holding its windows.
+func (cl *Client) truncateInputs(ctx context.Context, req *EmbeddingsRequest) ([]TruncatedInput, [][2]int, error) {
+	truncation := cl.cfg.InputTruncation
+	if truncation.Mode == TruncationNone {
+		return nil, nil, nil
+	}
+	maxTokens := truncation.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = ModelMaxTokens[req.Model]
+	}
+	if maxTokens <= 0 {
+		return nil, nil, nil
+	}
+	if truncation.Mode == TruncationWindows && (req.ReturnMultivector || !floatEmbeddings(req.EmbeddingType)) {
+		return nil, nil, fmt.Errorf("window truncation requires float single-vector embeddings")
+	}
+	split := truncation.Split
+	if split == nil {
+		split = ApproxTokenSplitter
+	}
+
+	var truncated []TruncatedInput
+	var windows [][2]int
+	inputs := make([]EmbeddingInput, 0, len(req.Input))
+	for i, input := range req.Input {
+		start := len(inputs)
+		if input.Text == "" {
+			inputs = append(inputs, input)
+			windows = append(windows, [2]int{start, len(inputs)})
+			continue
+		}
+
+		pieces, tokens, err := split(ctx, input.Text, maxTokens)
+		if err != nil {
+			return nil, nil, fmt.Errorf("input %d: %w", i, err)
+		}
+		if len(pieces) <= 1 {
+			inputs = append(inputs, input)
+			windows = append(windows, [2]int{start, len(inputs)})
+			continue
+		}
+
+		if truncation.Mode == TruncationHead {
+			inputs = append(inputs, NewEmbeddingInputText(pieces[0]))
+			truncated = append(truncated, TruncatedInput{Index: i, Tokens: tokens, Kept: min(tokens, maxTokens), Windows: 1})
+		} else {
+			for _, piece := range pieces {
+				inputs = append(inputs, NewEmbeddingInputText(piece))
+			}
+			truncated = append(truncated, TruncatedInput{Index: i, Tokens: tokens, Kept: tokens, Windows: len(pieces)})
+		}
+		windows = append(windows, [2]int{start, len(inputs)})
+	}
+	req.Input = inputs
+
+	if truncation.Mode != TruncationWindows || len(truncated) == 0 {
+		return truncated, nil, nil
+	}
+
+	return truncated, windows, nil
+}
+
+func floatEmbeddings(types []string) bool {
+	return len(types) == 0 || (len(types) == 1 && types[0] == "float")
+}
+
+// poolWindows replaces the window embeddings of resp with one mean pooled embedding per original input.
+func poolWindows(resp *EmbeddingsResponse, windows [][2]int) error {
+	byIndex := make(map[int][]float32, len(resp.Data))
+	for _, data := range resp.Data {
+		byIndex[data.Index] = data.Embedding
+	}
+
+	pooled := make([]EmbeddingData, len(windows))
+	for i, window := range windows {
+		var sum []float32
+		for j := window[0]; j < window[1]; j++ {
+			embedding, ok := byIndex[j]
+			if !ok {
+				return fmt.Errorf("response is missing the embedding of window %d of input %d", j-window[0], i)
+			}
+			if sum == nil {
+				sum = make([]float32, len(embedding))
+			}
+			for k := range min(len(sum), len(embedding)) {
+				sum[k] += embedding[k]
+			}
+		}
+		if window[1]-window[0] > 1 {
+			Normalize(sum)
+		}
+		pooled[i] = EmbeddingData{Object: "embedding", Index: i, Embedding: sum}
+	}
+	resp.Data = pooled
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package jina
+
+import "context"
+
+// UsageHook is called after every successful API call with the tokens it consumed.
+// For streaming calls it is called once, when the stream completes, with the last usage it reported.
+// Model is the requested model, or empty for endpoints without one.
+type UsageHook func(ctx context.Context, endpoint Endpoint, model string, usage Usage)
+
+// WithUsageHook registers a hook invoked with the usage of every successful call,
+// so billing and attribution systems can record consumption without wrapping every method.
+func WithUsageHook(hook UsageHook) Option {
+	return func(cfg *config) {
+		cfg.UsageHooks = append(cfg.UsageHooks, hook)
+	}
+}
+
+// Usage returns the recorder aggregating the usage of every successful call since the client was
+// created, or since it was last reset, by endpoint, model and tags. It is safe for concurrent use:
+//
+//	report := client.Usage().Report()
+//	fmt.Println(report.Total().TotalTokens, report.ByEndpoint()[jina.EndpointEmbeddings])
+//	client.Usage().Reset()
+func (cl *Client) Usage() *UsageRecorder {
+	return cl.usage
+}
+
+func (cl *Client) reportUsage(ctx context.Context, endpoint Endpoint, model string, usage Usage) {
+	cl.logUsage(ctx, endpoint, model, usage)
+	cl.usage.record(ctx, endpoint, model, usage)
+	for _, hook := range cl.cfg.UsageHooks {
+		hook(ctx, endpoint, model, usage)
+	}
+}
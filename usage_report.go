@@ -0,0 +1,264 @@
+package jina
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type tagsKey struct{}
+
+// WithTag returns a context attributing the usage of calls made with it to the given tags,
+// e.g. "team:search" or "feature:autocomplete", see UsageRecorder. Tags accumulate across calls.
+func WithTag(ctx context.Context, tags ...string) context.Context {
+	existing := TagsFromContext(ctx)
+	merged := slices.Concat(existing, tags)
+	slices.Sort(merged)
+
+	return context.WithValue(ctx, tagsKey{}, slices.Compact(merged))
+}
+
+// TagsFromContext returns the sorted tags set on ctx with WithTag.
+func TagsFromContext(ctx context.Context) []string {
+	tags, _ := ctx.Value(tagsKey{}).([]string)
+	return slices.Clone(tags)
+}
+
+// UsageRecorderOption configures a UsageRecorder.
+type UsageRecorderOption func(*UsageRecorder)
+
+// WithUsageRecorderClock replaces the wall clock used to time report periods. Defaults to SystemClock.
+func WithUsageRecorderClock(clock Clock) UsageRecorderOption {
+	return func(r *UsageRecorder) {
+		r.clock = clock
+	}
+}
+
+// WithUsageRecorderPricing replaces the prices used to estimate the cost of the recorded usage.
+// Defaults to DefaultPricing.
+func WithUsageRecorderPricing(pricing Pricing) UsageRecorderOption {
+	return func(r *UsageRecorder) {
+		r.pricing = pricing
+	}
+}
+
+// UsageRecorder aggregates the usage of a client by endpoint, model and tags, so spend can be
+// attributed per feature. Register it with WithUsageHook(recorder.Hook()).
+type UsageRecorder struct {
+	clock   Clock
+	pricing Pricing
+
+	mu    sync.Mutex
+	start time.Time
+	rows  map[usageKey]*UsageReportRow
+}
+
+type usageKey struct {
+	endpoint Endpoint
+	model    string
+	tags     string
+}
+
+// NewUsageRecorder returns an empty recorder whose first period starts now.
+func NewUsageRecorder(opts ...UsageRecorderOption) *UsageRecorder {
+	r := &UsageRecorder{clock: SystemClock, pricing: DefaultPricing}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.start = r.clock.Now()
+	r.rows = make(map[usageKey]*UsageReportRow)
+
+	return r
+}
+
+// Hook returns the UsageHook feeding the recorder.
+func (r *UsageRecorder) Hook() UsageHook {
+	return r.record
+}
+
+func (r *UsageRecorder) record(ctx context.Context, endpoint Endpoint, model string, usage Usage) {
+	tags := TagsFromContext(ctx)
+	key := usageKey{endpoint: endpoint, model: model, tags: strings.Join(tags, ",")}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	row, ok := r.rows[key]
+	if !ok {
+		row = &UsageReportRow{Endpoint: endpoint, Model: model, Tags: tags}
+		r.rows[key] = row
+	}
+	row.Calls++
+	row.Usage = addUsage(row.Usage, usage)
+	row.Cost += r.pricing.cost(endpoint, model, usage)
+}
+
+// Report returns the usage recorded in the current period.
+func (r *UsageRecorder) Report() UsageReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.report()
+}
+
+// Flush returns the usage recorded in the current period and starts a new one.
+func (r *UsageRecorder) Flush() UsageReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := r.report()
+	r.start = report.End
+	r.rows = make(map[usageKey]*UsageReportRow)
+
+	return report
+}
+
+// Reset discards the usage recorded in the current period and starts a new one.
+func (r *UsageRecorder) Reset() {
+	r.Flush()
+}
+
+// Export flushes a report to export every interval until ctx is done or export fails,
+// flushing the final partial period before returning.
+func (r *UsageRecorder) Export(ctx context.Context, interval time.Duration, export func(UsageReport) error) error {
+	for {
+		if err := r.clock.Sleep(ctx, interval); err != nil {
+			if exportErr := export(r.Flush()); exportErr != nil {
+				return exportErr
+			}
+			return err
+		}
+		if err := export(r.Flush()); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *UsageRecorder) report() UsageReport {
+	report := UsageReport{Start: r.start, End: r.clock.Now()}
+	for _, row := range r.rows {
+		row := *row
+		row.Tags = slices.Clone(row.Tags)
+		report.Rows = append(report.Rows, row)
+	}
+	slices.SortFunc(report.Rows, func(a, b UsageReportRow) int {
+		return cmp.Or(
+			cmp.Compare(a.Endpoint, b.Endpoint),
+			cmp.Compare(a.Model, b.Model),
+			slices.Compare(a.Tags, b.Tags),
+		)
+	})
+
+	return report
+}
+
+// UsageReport is the usage of a client over a period, broken down by endpoint, model and tags.
+type UsageReport struct {
+	Start time.Time        `json:"start"`
+	End   time.Time        `json:"end"`
+	Rows  []UsageReportRow `json:"rows"`
+}
+
+// UsageReportRow is the usage of the calls sharing an endpoint, model and set of tags.
+type UsageReportRow struct {
+	Endpoint Endpoint `json:"endpoint"`
+	Model    string   `json:"model,omitempty"` // Empty for endpoints without one
+	Tags     []string `json:"tags,omitempty"`
+	Calls    int      `json:"calls"`
+	Usage    Usage    `json:"usage"`
+
+	// Cost is the estimated cost in USD, see Pricing. Usage of models without a price costs nothing.
+	Cost float64 `json:"cost_usd"`
+}
+
+// Total sums the usage of every row.
+func (r UsageReport) Total() Usage {
+	var total Usage
+	for _, row := range r.Rows {
+		total = addUsage(total, row.Usage)
+	}
+
+	return total
+}
+
+// Cost sums the estimated cost in USD of every row.
+func (r UsageReport) Cost() float64 {
+	var cost float64
+	for _, row := range r.Rows {
+		cost += row.Cost
+	}
+
+	return cost
+}
+
+// ByTag sums the usage of the rows carrying each tag. Calls with several tags count towards each of them.
+func (r UsageReport) ByTag() map[string]Usage {
+	byTag := make(map[string]Usage)
+	for _, row := range r.Rows {
+		for _, tag := range row.Tags {
+			byTag[tag] = addUsage(byTag[tag], row.Usage)
+		}
+	}
+
+	return byTag
+}
+
+// ByEndpoint sums the usage of the rows of each endpoint.
+func (r UsageReport) ByEndpoint() map[Endpoint]Usage {
+	byEndpoint := make(map[Endpoint]Usage)
+	for _, row := range r.Rows {
+		byEndpoint[row.Endpoint] = addUsage(byEndpoint[row.Endpoint], row.Usage)
+	}
+
+	return byEndpoint
+}
+
+// ByModel sums the usage of the rows of each model. Calls to endpoints without a model are left out.
+func (r UsageReport) ByModel() map[string]Usage {
+	byModel := make(map[string]Usage)
+	for _, row := range r.Rows {
+		if row.Model != "" {
+			byModel[row.Model] = addUsage(byModel[row.Model], row.Usage)
+		}
+	}
+
+	return byModel
+}
+
+// WriteJSON writes the report as a JSON document.
+func (r UsageReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+// WriteCSV writes the report as CSV with a header row, one row per endpoint, model and tags.
+// Tags are joined with ";" and the period is repeated on every row, so reports can be concatenated.
+func (r UsageReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	records := [][]string{{"start", "end", "endpoint", "model", "tags", "calls", "total_tokens", "prompt_tokens", "completion_tokens", "cost_usd"}}
+	for _, row := range r.Rows {
+		records = append(records, []string{
+			r.Start.Format(time.RFC3339),
+			r.End.Format(time.RFC3339),
+			string(row.Endpoint),
+			row.Model,
+			strings.Join(row.Tags, ";"),
+			strconv.Itoa(row.Calls),
+			strconv.Itoa(row.Usage.TotalTokens),
+			strconv.Itoa(row.Usage.PromptTokens),
+			strconv.Itoa(row.Usage.CompletionTokens),
+			strconv.FormatFloat(row.Cost, 'f', -1, 64),
+		})
+	}
+
+	return cw.WriteAll(records)
+}
@@ -2,10 +2,13 @@ package jina
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 )
 
 const VLMModelDefault = "jina-vlm"
@@ -34,29 +37,45 @@ type VLMMessageContent struct {
 
 // MarshalJSON implements custom marshaling for VLMMessageContent.
 func (c VLMMessageContent) MarshalJSON() ([]byte, error) {
-	if len(c.Parts) > 0 {
+	if c.Parts != nil {
 		return json.Marshal(c.Parts)
 	}
 	return json.Marshal(c.Text)
 }
 
 // UnmarshalJSON implements custom unmarshaling for VLMMessageContent.
+// Null content, as sent in some stream deltas, is a no-op.
 func (c *VLMMessageContent) UnmarshalJSON(data []byte) error {
-	// Try unmarshaling as string first
-	var text string
-	if err := json.Unmarshal(data, &text); err == nil {
-		c.Text = text
+	if isJSONNull(data) {
 		return nil
 	}
-
-	// Try unmarshaling as parts
-	var parts []VLMContentPart
-	if err := json.Unmarshal(data, &parts); err == nil {
-		c.Parts = parts
+	switch kind := jsonKind(data); kind {
+	case "string":
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return fmt.Errorf("invalid VLMMessageContent: %w", err)
+		}
+		*c = VLMMessageContent{Text: text}
+		return nil
+	case "array":
+		var raw []json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("invalid VLMMessageContent: %w", err)
+		}
+		parts := make([]VLMContentPart, len(raw))
+		for i, part := range raw {
+			if kind := jsonKind(part); kind != "object" {
+				return fmt.Errorf("invalid VLMMessageContent: part %d is %s, not object", i, kind)
+			}
+			if err := json.Unmarshal(part, &parts[i]); err != nil {
+				return fmt.Errorf("invalid VLMMessageContent: part %d: %w", i, err)
+			}
+		}
+		*c = VLMMessageContent{Parts: parts}
 		return nil
+	default:
+		return fmt.Errorf("invalid VLMMessageContent: got %s, want string or array of parts", kind)
 	}
-
-	return fmt.Errorf("invalid VLMMessageContent: not a string or array of parts")
 }
 
 func NewVLMMessage(role, text string) VLMMessage {
@@ -77,6 +96,49 @@ func NewVLMMessageWithParts(role string, parts []VLMContentPart) VLMMessage {
 	}
 }
 
+// NewVLMImagesMessage creates a user message with text followed by several images, for prompts
+// comparing images ("which of these two screenshots shows the bug?"). Images are URLs, data URIs
+// or base64 strings, and are checked against DefaultLimits so oversized messages fail here
+// with a *LimitError rather than at the API.
+func NewVLMImagesMessage(text string, images ...string) (VLMMessage, error) {
+	if len(images) == 0 {
+		return VLMMessage{}, fmt.Errorf("at least one image is required")
+	}
+
+	parts := make([]VLMContentPart, 0, len(images)+1)
+	if text != "" {
+		parts = append(parts, NewVLMTextPart(text))
+	}
+	for i, image := range images {
+		if image == "" {
+			return VLMMessage{}, fmt.Errorf("image %d is empty", i)
+		}
+		parts = append(parts, NewVLMImagePart(image))
+	}
+
+	message := NewVLMMessageWithParts("user", parts)
+	if err := DefaultLimits.checkVLM([]VLMMessage{message}); err != nil {
+		return VLMMessage{}, err
+	}
+
+	return message, nil
+}
+
+// NewVLMTextPart creates a text content part.
+func NewVLMTextPart(text string) VLMContentPart {
+	return VLMContentPart{Type: "text", Text: text}
+}
+
+// NewVLMImagePart creates an image content part from a URL, data URI or base64 string.
+func NewVLMImagePart(imageURLOrBase64 string) VLMContentPart {
+	return VLMContentPart{Type: "image_url", ImageURL: &VLMImageURL{URL: imageURLOrBase64}}
+}
+
+// NewVLMImagePartWithDetail creates an image content part viewed at the given detail level.
+func NewVLMImagePartWithDetail(imageURLOrBase64 string, detail VLMImageDetail) VLMContentPart {
+	return VLMContentPart{Type: "image_url", ImageURL: &VLMImageURL{URL: imageURLOrBase64, Detail: detail}}
+}
+
 // VLMContentPart represents a part of the message content (text or image).
 type VLMContentPart struct {
 	Type     string       `json:"type"`
@@ -86,8 +148,20 @@ type VLMContentPart struct {
 
 type VLMImageURL struct {
 	URL string `json:"url"`
+
+	// Detail trades token cost against visual fidelity for the image. Default: the API's choice (auto).
+	Detail VLMImageDetail `json:"detail,omitempty"`
 }
 
+// VLMImageDetail is the resolution at which the model looks at an image.
+type VLMImageDetail string
+
+const (
+	VLMImageDetailAuto VLMImageDetail = "auto" // The model picks the level based on the image size
+	VLMImageDetailLow  VLMImageDetail = "low"  // A low-resolution view, using fewer tokens
+	VLMImageDetailHigh VLMImageDetail = "high" // A high-resolution view, using more tokens
+)
+
 type VLMResponse struct {
 	ID      string      `json:"id"`
 	Object  string      `json:"object"`
@@ -95,6 +169,13 @@ type VLMResponse struct {
 	Model   string      `json:"model"`
 	Choices []VLMChoice `json:"choices"`
 	Usage   Usage       `json:"usage"`
+	Meta    Meta        `json:"-"` // Set on responses, not on streamed chunks
+
+	// Event is the server-sent event name of a streamed chunk, empty for unnamed events.
+	Event string `json:"-"`
+
+	// EventID is the last server-sent event ID of the stream at a streamed chunk, empty if none was sent.
+	EventID string `json:"-"`
 }
 
 type VLMChoice struct {
@@ -108,84 +189,114 @@ type VLMChoice struct {
 }
 
 // VLM calls the Jina VLM API for image understanding and multimodal chat.
-func (cl *Client) VLM(ctx context.Context, req VLMRequest) (*VLMResponse, error) {
-	url := "https://api-beta-vlm.jina.ai/v1/chat/completions"
-
+func (cl *Client) VLM(ctx context.Context, req VLMRequest, opts ...CallOption) (*VLMResponse, error) {
 	if req.Model == "" {
-		req.Model = VLMModelDefault
+		req.Model = cl.cfg.DefaultVLMModel
 	}
 	req.Stream = false // Force stream to false for synchronous call
-
-	jsonData, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "application/json")
-	if cl.cfg.APIKey != "" {
-		httpReq.Header.Set("Authorization", "Bearer "+cl.cfg.APIKey)
-	}
-
-	client := &http.Client{}
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	if err := cl.cfg.Limits.checkVLM(req.Messages); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		var errResp map[string]interface{}
-		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil {
-			return nil, fmt.Errorf("API error: %v", errResp)
-		}
-		return nil, fmt.Errorf("API error with status code: %d", resp.StatusCode)
-	}
+	return withModelFallback(cl, EndpointVLM, req.Model, func(model string) (*VLMResponse, error) {
+		req.Model = model
 
-	var result VLMResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+		var result VLMResponse
+		if err := cl.postJSON(ctx, EndpointVLM, req, &result, newCallConfig(opts)); err != nil {
+			return nil, err
+		}
+		if result.Model == "" {
+			result.Model = model
+		}
+		cl.reportUsage(ctx, EndpointVLM, model, result.Usage)
 
-	return &result, nil
+		return &result, nil
+	})
 }
 
 // VLMStream calls the Jina VLM API with streaming enabled.
 // The callback function is invoked for each chunk of the response.
-func (cl *Client) VLMStream(ctx context.Context, req VLMRequest, callback func(*VLMResponse) error) error {
-	url := "https://api-beta-vlm.jina.ai/v1/chat/completions"
-
+func (cl *Client) VLMStream(ctx context.Context, req VLMRequest, callback func(*VLMResponse) error, opts ...CallOption) error {
 	if req.Model == "" {
-		req.Model = VLMModelDefault
+		req.Model = cl.cfg.DefaultVLMModel
 	}
 	req.Stream = true
+	if err := cl.cfg.Limits.checkVLM(req.Messages); err != nil {
+		return err
+	}
 
-	jsonData, err := json.Marshal(req)
+	call := newCallConfig(opts)
+	index := 0
+	// Streams may repeat the cumulative usage on several chunks, only the last one is reported
+	err := cl.postStream(ctx, EndpointVLM, req, call, func(event streamEvent) error {
+		var chunk VLMResponse
+		index++
+		if err := json.Unmarshal(event.Data, &chunk); err != nil {
+			return &StreamChunkError{Endpoint: EndpointVLM, Index: index - 1, Event: event.Name, Data: bytes.Clone(event.Data), Err: err}
+		}
+		chunk.Event = event.Name
+		chunk.EventID = event.ID
+		if chunk.Usage.TotalTokens > 0 {
+			call.usage = chunk.Usage
+		}
+		return callback(&chunk)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
+		return err
 	}
+	cl.reportUsage(ctx, EndpointVLM, req.Model, call.usage)
+
+	return nil
+}
+
+// VLMStreamTo streams the VLM response, writing the content deltas of the first choice to w as they
+// arrive, and returns the assembled response with the full content as its message.
+// If w implements http.Flusher, it is flushed after every delta.
+func (cl *Client) VLMStreamTo(ctx context.Context, req VLMRequest, w io.Writer, opts ...CallOption) (*VLMResponse, error) {
+	flusher, _ := w.(http.Flusher)
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
+	var result VLMResponse
+	var content strings.Builder
+	finishReason := ""
+	err := cl.VLMStream(ctx, req, func(chunk *VLMResponse) error {
+		if result.ID == "" {
+			result.ID, result.Created, result.Model = chunk.ID, chunk.Created, chunk.Model
+		}
+		if chunk.Usage.TotalTokens > 0 {
+			result.Usage = chunk.Usage
+		}
+		if len(chunk.Choices) == 0 {
+			return nil
+		}
+
+		choice := chunk.Choices[0]
+		if choice.FinishReason != "" {
+			finishReason = choice.FinishReason
+		}
+		if choice.Delta.Content == "" {
+			return nil
+		}
+		content.WriteString(choice.Delta.Content)
+		if _, err := io.WriteString(w, choice.Delta.Content); err != nil {
+			return fmt.Errorf("write delta: %w", err)
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	}, opts...)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "text/event-stream")
-	if cl.cfg.APIKey != "" {
-		httpReq.Header.Set("Authorization", "Bearer "+cl.cfg.APIKey)
+	if result.Model == "" {
+		result.Model = cmp.Or(req.Model, cl.cfg.DefaultVLMModel)
 	}
+	result.Object = "chat.completion"
+	result.Choices = []VLMChoice{{
+		Message:      NewVLMMessage("assistant", content.String()),
+		FinishReason: finishReason,
+	}}
 
-	return cl.doStream(httpReq, func(data []byte) error {
-		var chunk VLMResponse
-		if err := json.Unmarshal(data, &chunk); err != nil {
-			return fmt.Errorf("failed to unmarshal chunk: %w", err)
-		}
-		return callback(&chunk)
-	})
+	return &result, nil
 }
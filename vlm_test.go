@@ -0,0 +1,96 @@
+package jina
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// vlmStream holds chunks streamed by the VLM API, and vlmRequest a request with image parts,
+// as echoed back by the API in error reports.
+const (
+	vlmStream = `[
+		{"id":"chatcmpl-1","object":"chat.completion.chunk","created":1745000000,"model":"jina-vlm","choices":[{"index":0,"delta":{"role":"assistant","content":""},"finish_reason":null}]},
+		{"id":"chatcmpl-1","object":"chat.completion.chunk","created":1745000000,"model":"jina-vlm","choices":[{"index":0,"delta":{"content":"The image shows"},"finish_reason":null}]},
+		{"id":"chatcmpl-1","object":"chat.completion.chunk","created":1745000000,"model":"jina-vlm","choices":[{"index":0,"delta":{"content":null},"finish_reason":"stop"}],"usage":{"prompt_tokens":812,"completion_tokens":41,"total_tokens":853}}
+	]`
+	vlmRequest = `{"model":"jina-vlm","messages":[
+		{"role":"system","content":"You are a helpful assistant."},
+		{"role":"user","content":[
+			{"type":"text","text":"What is in this image?"},
+			{"type":"image_url","image_url":{"url":"https://picsum.photos/id/237/536/354","detail":"high"}},
+			{"type":"image_url","image_url":{"url":"data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNkYPhfDwAChwGA60e6kgAAAABJRU5ErkJggg=="}}
+		]}
+	]}`
+)
+
+func FuzzVLMMessageContentUnmarshal(f *testing.F) {
+	var chunks []struct {
+		Choices []struct {
+			Delta struct {
+				Content json.RawMessage `json:"content"`
+			} `json:"delta"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal([]byte(vlmStream), &chunks); err != nil {
+		f.Fatal(err)
+	}
+	for _, chunk := range chunks {
+		f.Add([]byte(chunk.Choices[0].Delta.Content))
+	}
+	var req struct {
+		Messages []struct {
+			Content json.RawMessage `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal([]byte(vlmRequest), &req); err != nil {
+		f.Fatal(err)
+	}
+	for _, message := range req.Messages {
+		f.Add([]byte(message.Content))
+	}
+	for _, seed := range []string{
+		`"hello"`,
+		`[]`,
+		`[{"type":"text","text":"hello"}]`,
+		`[{"type":"image_url","image_url":null}]`,
+		`null`,
+		`[null]`,
+		`[null,null]`,
+		`[[{"type":"text"}]]`,
+		`[[[[]]]]`,
+		`[{"type":"text","text":1e400}]`,
+		`1e400`,
+		`99999999999999999999999`,
+		`{"type":"text"}`,
+		`true`,
+		``,
+		`nope`,
+		`[{"type":`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var content VLMMessageContent
+		if err := content.UnmarshalJSON(data); err != nil {
+			if !strings.HasPrefix(err.Error(), "invalid VLMMessageContent") {
+				t.Errorf("UnmarshalJSON(%q) error %q does not report invalid content", data, err)
+			}
+			return
+		}
+
+		encoded, err := json.Marshal(content)
+		if err != nil {
+			t.Fatalf("Marshal(%#v): %v", content, err)
+		}
+		var decoded VLMMessageContent
+		if err := decoded.UnmarshalJSON(encoded); err != nil {
+			t.Fatalf("UnmarshalJSON(%s) of re-encoded %q: %v", encoded, data, err)
+		}
+		if !reflect.DeepEqual(decoded, content) {
+			t.Errorf("round trip of %q through %s = %#v, want %#v", data, encoded, decoded, content)
+		}
+	})
+}